@@ -0,0 +1,84 @@
+package spacex
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/catdevman/go-spacex/spacex/query"
+)
+
+// defaultGetManyBatchSize is the maximum number of IDs looked up per query
+// request, unless set with WithGetManyBatchSize.
+const defaultGetManyBatchSize = 100
+
+// MissingIDsError is returned by the GetMany methods when some of the
+// requested IDs don't exist. The records that were found are returned along
+// with it. It matches ErrNotFound.
+type MissingIDsError struct {
+	Service string   // service looked up, such as "payloads"
+	IDs     []string // missing IDs, in request order
+}
+
+func (e *MissingIDsError) Error() string {
+	return fmt.Sprintf("spacex: %d %s not found: %s", len(e.IDs), e.Service, strings.Join(e.IDs, ", "))
+}
+
+// Is reports whether target is ErrNotFound.
+func (e *MissingIDsError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// getMany looks up the records of service with the given IDs using queries
+// with an $in filter, a batch of IDs at a time. The records are
+// returned in the order of ids, with nil for IDs that don't exist, which are
+// reported in a *MissingIDsError. The Response is that of the last query.
+func getMany[T any](ctx context.Context, c *Client, service string, ids []string, id func(*T) string, opts []CallOption) ([]*T, *Response, error) {
+	var unique []string
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	batchSize := c.getManyBatchSize
+	if batchSize <= 0 {
+		batchSize = defaultGetManyBatchSize
+	}
+
+	found := make(map[string]*T, len(unique))
+	var resp *Response
+	for start := 0; start < len(unique); start += batchSize {
+		chunk := unique[start:min(start+batchSize, len(unique))]
+		q := query.New(query.In("_id", chunk...), &query.Options{Limit: len(chunk)})
+		req, err := c.newRequest(ctx, "POST", service+"/query", q, opts...)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		page := new(Page[*T])
+		resp, err = c.do(ctx, req, page)
+		if err != nil {
+			return nil, resp, err
+		}
+		for _, doc := range page.Docs {
+			found[id(doc)] = doc
+		}
+	}
+
+	results := make([]*T, len(ids))
+	var missing []string
+	for i, id := range ids {
+		results[i] = found[id]
+		if results[i] == nil && seen[id] {
+			seen[id] = false // report duplicates once
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		return results, resp, &MissingIDsError{Service: service, IDs: missing}
+	}
+	return results, resp, nil
+}
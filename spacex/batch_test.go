@@ -0,0 +1,88 @@
+package spacex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPayloadsService_GetMany(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	if err := WithGetManyBatchSize(2)(client); err != nil {
+		t.Fatalf("WithGetManyBatchSize returned error: %v", err)
+	}
+
+	existing := map[string]bool{"p1": true, "p2": true, "p3": true}
+	var chunks [][]string
+	mux.HandleFunc("/payloads/query", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query struct {
+				ID struct {
+					In []string `json:"$in"`
+				} `json:"_id"`
+			} `json:"query"`
+			Options struct {
+				Limit int `json:"limit"`
+			} `json:"options"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding query: %v", err)
+		}
+		ids := body.Query.ID.In
+		if body.Options.Limit != len(ids) {
+			t.Errorf("query limit = %d, want %d", body.Options.Limit, len(ids))
+		}
+		chunks = append(chunks, ids)
+
+		// Answer out of order, as the API sorts by its own criteria.
+		var docs []string
+		for i := len(ids) - 1; i >= 0; i-- {
+			if existing[ids[i]] {
+				docs = append(docs, fmt.Sprintf(`{"id":%q}`, ids[i]))
+			}
+		}
+		fmt.Fprintf(w, `{"docs":[%s],"totalDocs":%d}`, strings.Join(docs, ","), len(docs))
+	})
+
+	ids := []string{"p3", "missing", "p1", "p3", "p2"}
+	payloads, _, err := client.Payloads.GetMany(context.Background(), ids)
+
+	var missingErr *MissingIDsError
+	if !errors.As(err, &missingErr) || !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Payloads.GetMany returned error %v, want *MissingIDsError", err)
+	}
+	if want := []string{"missing"}; !reflect.DeepEqual(missingErr.IDs, want) {
+		t.Errorf("missing IDs = %v, want %v", missingErr.IDs, want)
+	}
+	if want := [][]string{{"p3", "missing"}, {"p1", "p2"}}; !reflect.DeepEqual(chunks, want) {
+		t.Errorf("queried chunks %v, want %v", chunks, want)
+	}
+
+	if len(payloads) != len(ids) {
+		t.Fatalf("Payloads.GetMany returned %d payloads, want %d", len(payloads), len(ids))
+	}
+	for i, id := range ids {
+		switch {
+		case id == "missing" && payloads[i] != nil:
+			t.Errorf("payloads[%d] = %+v, want nil", i, payloads[i])
+		case id != "missing" && (payloads[i] == nil || payloads[i].ID != id):
+			t.Errorf("payloads[%d] = %+v, want ID %q", i, payloads[i], id)
+		}
+	}
+}
+
+func TestCrewService_GetManyEmpty(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	crew, _, err := client.Crew.GetMany(context.Background(), nil)
+	if err != nil || len(crew) != 0 {
+		t.Errorf("Crew.GetMany(nil) = %v, %v; want no results and no error", crew, err)
+	}
+}
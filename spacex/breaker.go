@@ -0,0 +1,200 @@
+package spacex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is matched by errors.Is for requests rejected by an open
+// circuit breaker.
+var ErrCircuitOpen = errors.New("spacex: circuit breaker is open")
+
+// CircuitOpenError is returned for requests rejected without contacting the
+// API because the circuit breaker of their endpoint family is open.
+type CircuitOpenError struct {
+	Family  string    // endpoint family whose circuit is open, such as "launches"
+	RetryAt time.Time // time at which a trial request will be allowed
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("spacex: circuit breaker for %q is open until %v", e.Family, e.RetryAt.Format(time.RFC3339))
+}
+
+// Is reports whether target is ErrCircuitOpen.
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState int
+
+// Circuit breaker states.
+const (
+	// CircuitClosed lets requests through and counts failures.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects requests until the cool-down has elapsed.
+	CircuitOpen
+	// CircuitHalfOpen lets a single trial request through to decide
+	// whether to close or reopen the circuit.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("CircuitState(%d)", int(s))
+}
+
+// CircuitBreakerSettings configures a CircuitBreaker.
+type CircuitBreakerSettings struct {
+	// FailureThreshold is the number of consecutive failures opening the
+	// circuit. Failures are connection errors and 5xx responses.
+	FailureThreshold int
+
+	// CoolDown is how long the circuit stays open before a trial request
+	// is let through.
+	CoolDown time.Duration
+
+	// Family maps an endpoint path, such as "launches/next", to the family
+	// sharing a circuit. The default uses the service, such as "launches",
+	// so an outage of one service doesn't block the others.
+	Family func(endpoint string) string
+}
+
+// CircuitBreaker stops sending requests to an endpoint family after repeated
+// failures, failing fast with a *CircuitOpenError until a trial request
+// succeeds. It is safe for concurrent use.
+type CircuitBreaker struct {
+	settings CircuitBreakerSettings
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+type circuit struct {
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	trial    bool // a half-open trial request is in flight
+}
+
+// NewCircuitBreaker returns a CircuitBreaker configured by settings. Zero
+// values default to 5 failures and a 30 second cool-down.
+func NewCircuitBreaker(settings CircuitBreakerSettings) *CircuitBreaker {
+	if settings.FailureThreshold <= 0 {
+		settings.FailureThreshold = 5
+	}
+	if settings.CoolDown <= 0 {
+		settings.CoolDown = 30 * time.Second
+	}
+	if settings.Family == nil {
+		settings.Family = serviceFamily
+	}
+	return &CircuitBreaker{
+		settings: settings,
+		circuits: make(map[string]*circuit),
+	}
+}
+
+// serviceFamily returns the service part of an endpoint path.
+func serviceFamily(endpoint string) string {
+	service, _, _ := strings.Cut(endpoint, "/")
+	return service
+}
+
+// State returns the current state of the circuit of family.
+func (b *CircuitBreaker) State(family string) CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[family]
+	if !ok {
+		return CircuitClosed
+	}
+	if c.state == CircuitOpen && time.Since(c.openedAt) >= b.settings.CoolDown {
+		return CircuitHalfOpen
+	}
+	return c.state
+}
+
+// States returns the state of every family that has seen requests, for
+// health checks.
+func (b *CircuitBreaker) States() map[string]CircuitState {
+	b.mu.Lock()
+	families := make([]string, 0, len(b.circuits))
+	for f := range b.circuits {
+		families = append(families, f)
+	}
+	b.mu.Unlock()
+
+	states := make(map[string]CircuitState, len(families))
+	for _, f := range families {
+		states[f] = b.State(f)
+	}
+	return states
+}
+
+// allow reports whether a request to endpoint may be sent, returning the
+// family to report the outcome for.
+func (b *CircuitBreaker) allow(endpoint string) (string, error) {
+	family := b.settings.Family(endpoint)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[family]
+	if !ok {
+		c = new(circuit)
+		b.circuits[family] = c
+	}
+
+	switch c.state {
+	case CircuitOpen:
+		if time.Since(c.openedAt) < b.settings.CoolDown {
+			return family, &CircuitOpenError{Family: family, RetryAt: c.openedAt.Add(b.settings.CoolDown)}
+		}
+		c.state = CircuitHalfOpen
+		c.trial = true
+	case CircuitHalfOpen:
+		if c.trial {
+			return family, &CircuitOpenError{Family: family, RetryAt: time.Now().Add(b.settings.CoolDown)}
+		}
+		c.trial = true
+	}
+	return family, nil
+}
+
+// record reports the outcome of a request allowed for family. Connection
+// errors and 5xx responses count as failures; canceled requests are ignored.
+func (b *CircuitBreaker) record(family string, resp *http.Response, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuits[family]
+	c.trial = false
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return
+	}
+	if err == nil && resp.StatusCode < 500 {
+		c.state = CircuitClosed
+		c.failures = 0
+		return
+	}
+
+	c.failures++
+	if c.state == CircuitHalfOpen || c.failures >= b.settings.FailureThreshold {
+		c.state = CircuitOpen
+		c.openedAt = time.Now()
+	}
+}
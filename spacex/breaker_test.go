@@ -0,0 +1,93 @@
+package spacex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.CircuitBreaker = NewCircuitBreaker(CircuitBreakerSettings{
+		FailureThreshold: 2,
+		CoolDown:         50 * time.Millisecond,
+	})
+
+	healthy := false
+	calls := 0
+	mux.HandleFunc("/launches/latest", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if !healthy {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `{"name":"Crew-9"}`)
+	})
+	mux.HandleFunc("/starlink/5eed770f096e59000698560d", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"version":"v1.0"}`)
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Launches.GetLatestLaunch(ctx); !errors.Is(err, ErrServer) {
+			t.Fatalf("Launches.GetLatestLaunch returned %v, want ErrServer", err)
+		}
+	}
+	if got := client.CircuitBreaker.State("launches"); got != CircuitOpen {
+		t.Fatalf("State(launches) = %v, want %v", got, CircuitOpen)
+	}
+
+	_, _, err := client.Launches.GetLatestLaunch(ctx)
+	var openErr *CircuitOpenError
+	if !errors.Is(err, ErrCircuitOpen) || !errors.As(err, &openErr) || openErr.Family != "launches" {
+		t.Fatalf("Launches.GetLatestLaunch returned %v, want *CircuitOpenError for launches", err)
+	}
+	if calls != 2 {
+		t.Errorf("server saw %d calls, want 2", calls)
+	}
+
+	// Other families are unaffected.
+	if _, _, err := client.Starlink.GetStarlink(ctx, "5eed770f096e59000698560d"); err != nil {
+		t.Errorf("Starlink.GetStarlink returned error: %v", err)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if got := client.CircuitBreaker.State("launches"); got != CircuitHalfOpen {
+		t.Errorf("State(launches) = %v, want %v", got, CircuitHalfOpen)
+	}
+
+	healthy = true
+	if _, _, err := client.Launches.GetLatestLaunch(ctx); err != nil {
+		t.Fatalf("trial request returned error: %v", err)
+	}
+	if got := client.CircuitBreaker.States(); got["launches"] != CircuitClosed || got["starlink"] != CircuitClosed {
+		t.Errorf("States() = %v, want all closed", got)
+	}
+}
+
+func TestCircuitBreaker_HalfOpenFailureReopens(t *testing.T) {
+	b := NewCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 1, CoolDown: 20 * time.Millisecond})
+	failed := &http.Response{StatusCode: http.StatusServiceUnavailable}
+
+	family, err := b.allow("starlink/query")
+	if err != nil {
+		t.Fatalf("allow returned error: %v", err)
+	}
+	b.record(family, failed, nil)
+
+	time.Sleep(25 * time.Millisecond)
+	if _, err := b.allow("starlink"); err != nil {
+		t.Fatalf("trial request rejected: %v", err)
+	}
+	if _, err := b.allow("starlink"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("second concurrent trial returned %v, want ErrCircuitOpen", err)
+	}
+	b.record("starlink", failed, nil)
+	if got := b.State("starlink"); got != CircuitOpen {
+		t.Errorf("State = %v, want %v", got, CircuitOpen)
+	}
+}
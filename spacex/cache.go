@@ -0,0 +1,334 @@
+package spacex
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores raw API response bodies. Implementations must be safe for
+// concurrent use. Caching is best effort: implementations should treat
+// storage errors as cache misses rather than report them.
+type Cache interface {
+	// Get returns the value stored for key, and false if there is no
+	// unexpired value.
+	Get(key string) ([]byte, bool)
+
+	// Set stores value for key, expiring after ttl.
+	Set(key string, value []byte, ttl time.Duration)
+
+	// Delete removes the value stored for key, if any.
+	Delete(key string)
+}
+
+// CachePolicy determines how long responses are cached for.
+type CachePolicy struct {
+	// DefaultTTL applies to endpoints not listed in TTLs. Zero disables
+	// caching of those endpoints.
+	DefaultTTL time.Duration
+
+	// TTLs maps endpoint paths relative to the base URL, such as "rockets"
+	// or "launches/next", to the time their responses are cached for. The
+	// longest matching path prefix wins, so "launches/next" overrides
+	// "launches". A zero TTL disables caching for the endpoint.
+	TTLs map[string]time.Duration
+}
+
+// DefaultCachePolicy returns a CachePolicy that caches rarely changing
+// resources for a day and launch data for short periods.
+func DefaultCachePolicy() *CachePolicy {
+	return &CachePolicy{
+		DefaultTTL: 5 * time.Minute,
+		TTLs: map[string]time.Duration{
+			"company":           24 * time.Hour,
+			"dragons":           24 * time.Hour,
+			"roadster":          time.Hour,
+			"rockets":           24 * time.Hour,
+			"launches":          10 * time.Minute,
+			"launches/latest":   time.Minute,
+			"launches/next":     time.Minute,
+			"launches/upcoming": time.Minute,
+			"starlink":          time.Hour,
+		},
+	}
+}
+
+// ttl returns the TTL for the given endpoint path.
+func (p *CachePolicy) ttl(endpoint string) time.Duration {
+	ttl, best := p.DefaultTTL, -1
+	for prefix, d := range p.TTLs {
+		if endpoint != prefix && !strings.HasPrefix(endpoint, prefix+"/") {
+			continue
+		}
+		if len(prefix) > best {
+			ttl, best = d, len(prefix)
+		}
+	}
+	return ttl
+}
+
+// cacheKey returns the cache key and TTL for req, or an empty key if the
+// request must not be cached.
+func (c *Client) cacheKey(req *http.Request) (string, time.Duration) {
+	if c.Cache == nil {
+		return "", 0
+	}
+
+	p := c.CachePolicy
+	if p == nil {
+		p = DefaultCachePolicy()
+	}
+	ttl := p.ttl(c.endpoint(req))
+	if ttl <= 0 {
+		return "", 0
+	}
+
+	key := c.requestKey(req)
+	if key == "" {
+		return "", 0
+	}
+	return key, ttl
+}
+
+// requestKey returns a key identifying the response to req, or an empty key
+// if req may change data on the server. GET requests are keyed by URL, and
+// queries by URL and a hash of the request body. Headers set with
+// WithHeader are hashed into the key, as they may change the response.
+func (c *Client) requestKey(req *http.Request) string {
+	switch {
+	case req.Method == "GET":
+	case req.Method == "POST" && strings.HasSuffix(c.endpoint(req), "/query"):
+	default:
+		return ""
+	}
+
+	key := req.Method + " " + req.URL.String()
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return ""
+		}
+		defer body.Close()
+		h := sha256.New()
+		if _, err := io.Copy(h, body); err != nil {
+			return ""
+		}
+		key += " " + hex.EncodeToString(h.Sum(nil))
+	}
+	if header := callOptionsFrom(req.Context()).header; len(header) > 0 {
+		h := sha256.New()
+		for _, k := range slices.Sorted(maps.Keys(header)) {
+			fmt.Fprintf(h, "%s: %q\n", k, header[k])
+		}
+		key += " h=" + hex.EncodeToString(h.Sum(nil))
+	}
+	return key
+}
+
+// newCachedResponse returns a Response for a body served from the cache.
+func newCachedResponse(req *http.Request, body []byte) *Response {
+	return &Response{
+		Response: &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        make(http.Header),
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		},
+		FromCache: true,
+	}
+}
+
+// MemoryCache is an in-memory Cache evicting the least recently used entries
+// once it holds the maximum number of entries.
+type MemoryCache struct {
+	lru *lru[[]byte]
+}
+
+// NewMemoryCache returns a MemoryCache holding at most maxEntries entries.
+// A maxEntries of zero means no limit.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{lru: newLRU[[]byte](maxEntries)}
+}
+
+// Get implements Cache.
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	return m.lru.get(key)
+}
+
+// Set implements Cache.
+func (m *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	m.lru.set(key, value, ttl)
+}
+
+// Delete implements Cache.
+func (m *MemoryCache) Delete(key string) {
+	m.lru.delete(key)
+}
+
+// Len returns the number of entries in the cache, including expired entries
+// that have not been evicted yet.
+func (m *MemoryCache) Len() int {
+	return m.lru.len()
+}
+
+// lru is a map of values of type V with expiry times, evicting the least
+// recently used entries once it holds the maximum number of entries. It is
+// safe for concurrent use.
+type lru[V any] struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List
+	items      map[string]*list.Element
+}
+
+type lruEntry[V any] struct {
+	key     string
+	value   V
+	expires time.Time
+}
+
+// newLRU returns an lru holding at most maxEntries entries, or any number
+// if maxEntries is zero.
+func newLRU[V any](maxEntries int) *lru[V] {
+	return &lru[V]{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+func (m *lru[V]) get(key string) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var zero V
+	el, ok := m.items[key]
+	if !ok {
+		return zero, false
+	}
+	e := el.Value.(*lruEntry[V])
+	if time.Now().After(e.expires) {
+		m.removeElement(el)
+		return zero, false
+	}
+	m.ll.MoveToFront(el)
+	return e.value, true
+}
+
+func (m *lru[V]) set(key string, value V, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expires := time.Now().Add(ttl)
+	if el, ok := m.items[key]; ok {
+		m.ll.MoveToFront(el)
+		e := el.Value.(*lruEntry[V])
+		e.value, e.expires = value, expires
+		return
+	}
+
+	m.items[key] = m.ll.PushFront(&lruEntry[V]{key: key, value: value, expires: expires})
+	if m.maxEntries > 0 && m.ll.Len() > m.maxEntries {
+		m.removeElement(m.ll.Back())
+	}
+}
+
+func (m *lru[V]) delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.items[key]; ok {
+		m.removeElement(el)
+	}
+}
+
+func (m *lru[V]) len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.ll.Len()
+}
+
+func (m *lru[V]) removeElement(el *list.Element) {
+	m.ll.Remove(el)
+	delete(m.items, el.Value.(*lruEntry[V]).key)
+}
+
+// FileCache is a Cache storing entries as files in a directory, so cached
+// responses survive process restarts.
+type FileCache struct {
+	dir string
+}
+
+// NewFileCache returns a FileCache storing entries in dir, creating the
+// directory if needed.
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileCache{dir: dir}, nil
+}
+
+func (f *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(f.dir, hex.EncodeToString(sum[:]))
+}
+
+// Get implements Cache.
+func (f *FileCache) Get(key string) ([]byte, bool) {
+	data, err := os.ReadFile(f.path(key))
+	if err != nil || len(data) < 8 {
+		return nil, false
+	}
+	expires := time.Unix(0, int64(binary.BigEndian.Uint64(data)))
+	if time.Now().After(expires) {
+		f.Delete(key)
+		return nil, false
+	}
+	return data[8:], true
+}
+
+// Set implements Cache. Entries are written to a temporary file first and
+// renamed into place, so concurrent readers never see partial entries.
+func (f *FileCache) Set(key string, value []byte, ttl time.Duration) {
+	tmp, err := os.CreateTemp(f.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	var header [8]byte
+	binary.BigEndian.PutUint64(header[:], uint64(time.Now().Add(ttl).UnixNano()))
+	_, err = tmp.Write(header[:])
+	if err == nil {
+		_, err = tmp.Write(value)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return
+	}
+	os.Rename(tmp.Name(), f.path(key))
+}
+
+// Delete implements Cache.
+func (f *FileCache) Delete(key string) {
+	os.Remove(f.path(key))
+}
@@ -0,0 +1,126 @@
+package spacex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestMemoryCache(t *testing.T) {
+	c := NewMemoryCache(2)
+	c.Set("a", []byte("1"), time.Hour)
+	c.Set("b", []byte("2"), time.Hour)
+	c.Get("a") // a is now the most recently used entry
+	c.Set("c", []byte("3"), time.Hour)
+
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("Get(b) found evicted entry")
+	}
+	if v, ok := c.Get("a"); !ok || string(v) != "1" {
+		t.Errorf("Get(a) = %q, %v, want %q, true", v, ok, "1")
+	}
+
+	c.Set("d", []byte("4"), -time.Second)
+	if _, ok := c.Get("d"); ok {
+		t.Errorf("Get(d) found expired entry")
+	}
+
+	c.Delete("a")
+	if _, ok := c.Get("a"); ok {
+		t.Errorf("Get(a) found deleted entry")
+	}
+}
+
+func TestFileCache(t *testing.T) {
+	c, err := NewFileCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileCache returned error: %v", err)
+	}
+
+	c.Set("GET https://api.spacexdata.com/v4/rockets", []byte(`[]`), time.Hour)
+	if v, ok := c.Get("GET https://api.spacexdata.com/v4/rockets"); !ok || string(v) != "[]" {
+		t.Errorf("Get = %q, %v, want %q, true", v, ok, "[]")
+	}
+
+	c.Set("expired", []byte("x"), -time.Second)
+	if _, ok := c.Get("expired"); ok {
+		t.Errorf("Get(expired) found expired entry")
+	}
+
+	c.Delete("GET https://api.spacexdata.com/v4/rockets")
+	if _, ok := c.Get("GET https://api.spacexdata.com/v4/rockets"); ok {
+		t.Errorf("Get found deleted entry")
+	}
+}
+
+func TestCachePolicy_TTL(t *testing.T) {
+	p := DefaultCachePolicy()
+	tests := []struct {
+		endpoint string
+		want     time.Duration
+	}{
+		{"rockets", 24 * time.Hour},
+		{"rockets/5e9d0d95eda69955f709d1eb", 24 * time.Hour},
+		{"launches/next", time.Minute},
+		{"launches/5eb87cd9ffd86e000604b32a", 10 * time.Minute},
+		{"launchesx", p.DefaultTTL},
+		{"cores", p.DefaultTTL},
+	}
+
+	for _, tt := range tests {
+		if got := p.ttl(tt.endpoint); got != tt.want {
+			t.Errorf("ttl(%q) = %v, want %v", tt.endpoint, got, tt.want)
+		}
+	}
+}
+
+func TestDo_Cache(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.Cache = NewMemoryCache(0)
+	client.CachePolicy = &CachePolicy{TTLs: map[string]time.Duration{"rockets": time.Hour}}
+
+	calls := 0
+	mux.HandleFunc("/rockets/query", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"docs":[{"name":"Falcon 9"}]}`)
+	})
+	mux.HandleFunc("/cores", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `[]`)
+	})
+
+	ctx := context.Background()
+	q1 := map[string]interface{}{"query": map[string]interface{}{"active": true}}
+	q2 := map[string]interface{}{"query": map[string]interface{}{"active": false}}
+
+	if _, resp, err := client.Rockets.QueryRockets(ctx, q1); err != nil || resp.FromCache {
+		t.Fatalf("first query: err = %v, FromCache = %v", err, resp.FromCache)
+	}
+	results, resp, err := client.Rockets.QueryRockets(ctx, q1)
+	if err != nil {
+		t.Fatalf("Rockets.QueryRockets returned error: %v", err)
+	}
+	if !resp.FromCache {
+		t.Errorf("second identical query: FromCache = false, want true")
+	}
+	if len(results.Docs) != 1 || results.Docs[0].Name != "Falcon 9" {
+		t.Errorf("cached query returned %+v", results.Docs)
+	}
+	if _, _, err := client.Rockets.QueryRockets(ctx, q2); err != nil {
+		t.Fatalf("Rockets.QueryRockets returned error: %v", err)
+	}
+
+	// Cores have no TTL in the policy and are never cached.
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Cores.ListAllCores(ctx); err != nil {
+			t.Fatalf("Cores.ListAllCores returned error: %v", err)
+		}
+	}
+
+	if calls != 4 {
+		t.Errorf("server saw %d calls, want 4", calls)
+	}
+}
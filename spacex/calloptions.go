@@ -0,0 +1,94 @@
+package spacex
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// CallOption configures a single API call. Every service method accepts
+// CallOptions after its regular arguments.
+type CallOption func(*callOptions)
+
+// callOptions are the settings of a single API call, carried in the request
+// context.
+type callOptions struct {
+	header      http.Header
+	noCache     bool
+	timeout     time.Duration
+	retryPolicy *RetryPolicy
+	retrySet    bool
+}
+
+type callOptionsKey struct{}
+
+// WithHeader sets a header on the request of a call, replacing any value set
+// by the client.
+func WithHeader(key, value string) CallOption {
+	return func(o *callOptions) {
+		if o.header == nil {
+			o.header = make(http.Header)
+		}
+		o.header.Set(key, value)
+	}
+}
+
+// NoCache bypasses the client's Cache, conditional revalidation and request
+// coalescing, so the call fetches a fresh response from the API. The fresh
+// response still replaces any cached one.
+func NoCache() CallOption {
+	return func(o *callOptions) {
+		o.noCache = true
+	}
+}
+
+// WithCallTimeout bounds a call, including retries and reading the response,
+// to d. It applies in addition to any deadline of the call's context.
+func WithCallTimeout(d time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = d
+	}
+}
+
+// WithRetryPolicy overrides the client's RetryPolicy for a call. A nil policy
+// makes exactly one attempt.
+func WithRetryPolicy(p *RetryPolicy) CallOption {
+	return func(o *callOptions) {
+		o.retryPolicy = p
+		o.retrySet = true
+	}
+}
+
+// withCallOptions returns ctx carrying the options, if any.
+func withCallOptions(ctx context.Context, opts []CallOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+	o := new(callOptions)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return context.WithValue(ctx, callOptionsKey{}, o)
+}
+
+// callOptionsFrom returns the call options in ctx, or zero options.
+func callOptionsFrom(ctx context.Context) *callOptions {
+	if o, ok := ctx.Value(callOptionsKey{}).(*callOptions); ok {
+		return o
+	}
+	return new(callOptions)
+}
+
+// callContext returns the context to make the call for req in: ctx carrying
+// the call options of req and bounded by their timeout.
+func callContext(ctx context.Context, req *http.Request) (context.Context, context.CancelFunc) {
+	o, ok := req.Context().Value(callOptionsKey{}).(*callOptions)
+	if !ok {
+		return ctx, func() {}
+	}
+	ctx = context.WithValue(ctx, callOptionsKey{}, o)
+	if o.timeout > 0 {
+		return context.WithTimeout(ctx, o.timeout)
+	}
+	return ctx, func() {}
+}
@@ -0,0 +1,175 @@
+package spacex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type testContextKey struct{}
+
+func TestNewRequest_BindsContext(t *testing.T) {
+	c := NewClient(nil)
+	ctx := context.WithValue(context.Background(), testContextKey{}, "value")
+
+	req, err := c.newRequest(ctx, "GET", "company", nil, WithHeader("X-Trace", "abc"))
+	if err != nil {
+		t.Fatalf("newRequest returned error: %v", err)
+	}
+	if got := req.Context().Value(testContextKey{}); got != "value" {
+		t.Errorf("request context value = %v, want %q", got, "value")
+	}
+	if got := req.Header.Get("X-Trace"); got != "abc" {
+		t.Errorf("X-Trace header = %q, want %q", got, "abc")
+	}
+}
+
+func TestCallOption_WithHeader(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/company", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("User-Agent"); got != "custom-agent" {
+			t.Errorf("User-Agent header = %q, want %q", got, "custom-agent")
+		}
+		if got := r.Header.Get("X-Request-Id"); got != "42" {
+			t.Errorf("X-Request-Id header = %q, want %q", got, "42")
+		}
+		fmt.Fprint(w, `{"name":"SpaceX"}`)
+	})
+
+	_, _, err := client.Company.GetCompanyInfo(context.Background(),
+		WithHeader("User-Agent", "custom-agent"), WithHeader("X-Request-Id", "42"))
+	if err != nil {
+		t.Fatalf("Company.GetCompanyInfo returned error: %v", err)
+	}
+}
+
+func TestCallOption_NoCache(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.Cache = NewMemoryCache(10)
+
+	calls := 0
+	mux.HandleFunc("/roadster", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprintf(w, `{"name":"Roadster %d"}`, calls)
+	})
+
+	ctx := context.Background()
+	if _, _, err := client.Roadster.GetRoadsterInfo(ctx); err != nil {
+		t.Fatalf("Roadster.GetRoadsterInfo returned error: %v", err)
+	}
+	roadster, resp, err := client.Roadster.GetRoadsterInfo(ctx, NoCache())
+	if err != nil {
+		t.Fatalf("Roadster.GetRoadsterInfo returned error: %v", err)
+	}
+	if resp.FromCache || roadster.Name != "Roadster 2" {
+		t.Errorf("NoCache call got %q (FromCache %v), want a fresh %q", roadster.Name, resp.FromCache, "Roadster 2")
+	}
+
+	// The fresh response replaced the cached one.
+	roadster, resp, err = client.Roadster.GetRoadsterInfo(ctx)
+	if err != nil {
+		t.Fatalf("Roadster.GetRoadsterInfo returned error: %v", err)
+	}
+	if !resp.FromCache || roadster.Name != "Roadster 2" {
+		t.Errorf("cached call got %q (FromCache %v), want cached %q", roadster.Name, resp.FromCache, "Roadster 2")
+	}
+}
+
+func TestCallOption_WithHeaderCacheKey(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.Cache = NewMemoryCache(10)
+
+	mux.HandleFunc("/roadster", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name":"Roadster %s"}`, r.Header.Get("Accept-Language"))
+	})
+
+	ctx := context.Background()
+	for _, lang := range []string{"en", "fr", "en"} {
+		roadster, _, err := client.Roadster.GetRoadsterInfo(ctx, WithHeader("Accept-Language", lang))
+		if err != nil {
+			t.Fatalf("Roadster.GetRoadsterInfo returned error: %v", err)
+		}
+		if want := "Roadster " + lang; roadster.Name != want {
+			t.Errorf("Accept-Language %s: got %q, want %q", lang, roadster.Name, want)
+		}
+	}
+	if got := client.Cache.(*MemoryCache).Len(); got != 2 {
+		t.Errorf("cache holds %d entries, want 2", got)
+	}
+}
+
+func TestCallOption_WithHeaderConditionalRequests(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.EnableConditionalRequests()
+
+	mux.HandleFunc("/roadster", func(w http.ResponseWriter, r *http.Request) {
+		// The ETag identifies the version of the document, whatever its
+		// language.
+		w.Header().Set("ETag", `"1"`)
+		if r.Header.Get("If-None-Match") == `"1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprintf(w, `{"name":"Roadster %s"}`, r.Header.Get("Accept-Language"))
+	})
+
+	ctx := context.Background()
+	for _, lang := range []string{"en", "fr", "en", "fr"} {
+		roadster, _, err := client.Roadster.GetRoadsterInfo(ctx, WithHeader("Accept-Language", lang))
+		if err != nil {
+			t.Fatalf("Roadster.GetRoadsterInfo returned error: %v", err)
+		}
+		if want := "Roadster " + lang; roadster.Name != want {
+			t.Errorf("Accept-Language %s: got %q, want %q", lang, roadster.Name, want)
+		}
+	}
+}
+
+func TestCallOption_WithCallTimeout(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/starlink", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+
+	start := time.Now()
+	_, _, err := client.Starlink.ListAllStarlink(context.Background(), WithCallTimeout(20*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Starlink.ListAllStarlink returned %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("call took %v, want it to time out after 20ms", elapsed)
+	}
+}
+
+func TestCallOption_WithRetryPolicy(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+
+	attempts := 0
+	mux.HandleFunc("/ships", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, _, err := client.Ships.ListAllShips(context.Background(), WithRetryPolicy(nil))
+	if !errors.Is(err, ErrServer) {
+		t.Errorf("Ships.ListAllShips returned %v, want ErrServer", err)
+	}
+	if attempts != 1 {
+		t.Errorf("server saw %d attempts, want 1", attempts)
+	}
+}
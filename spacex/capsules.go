@@ -3,6 +3,9 @@ package spacex
 import (
 	"context"
 	"fmt"
+	"iter"
+
+	"github.com/catdevman/go-spacex/spacex/query"
 )
 
 // CapsulesService handles communication with the capsule related
@@ -22,38 +25,101 @@ type Capsule struct {
 	LandLandings  int      `json:"land_landings"`
 	LastUpdate    *string  `json:"last_update"`
 	Launches      []string `json:"launches"`
+	ID            string   `json:"id"`
+
+	Document
+}
+
+// MarshalJSON implements json.Marshaler, including the fields in Extra.
+func (c Capsule) MarshalJSON() ([]byte, error) {
+	type capsule Capsule
+	return marshalModel(capsule(c), c.Extra)
+}
+
+// PopulatedCapsule is a capsule whose launches may be populated with the launch
+// documents. The embedded Capsule holds the launch IDs.
+type PopulatedCapsule struct {
+	Capsule
+	Launches []Ref[Launch] `json:"launches"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting populated and
+// unpopulated launches.
+func (c *PopulatedCapsule) UnmarshalJSON(data []byte) error {
+	*c = PopulatedCapsule{}
+	refs := &struct {
+		Launches *[]Ref[Launch] `json:"launches"`
+	}{&c.Launches}
+	if err := unmarshalPopulated(data, &c.Capsule, refs, []string{"launches"}, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, including populated launches.
+func (c PopulatedCapsule) MarshalJSON() ([]byte, error) {
+	return marshalPopulated(c.Capsule, map[string]interface{}{"launches": c.Launches})
 }
 
+// CapsuleQueryResults represents the result of a capsule query.
+type CapsuleQueryResults = Page[*Capsule]
+
+// PopulatedCapsuleQueryResults represents the result of a populated capsule query.
+type PopulatedCapsuleQueryResults = Page[*PopulatedCapsule]
+
 // ListAllCapsules lists all capsules.
-func (s *CapsulesService) ListAllCapsules(ctx context.Context) ([]*Capsule, error) {
+func (s *CapsulesService) ListAllCapsules(ctx context.Context, opts ...CallOption) ([]*Capsule, *Response, error) {
 	u := "capsules"
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var capsules []*Capsule
-	_, err = s.client.do(ctx, req, &capsules)
+	resp, err := s.client.do(ctx, req, &capsules)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return capsules, nil
+	return capsules, resp, nil
+}
+
+// All iterates over all capsules, decoding them one at a time.
+func (s *CapsulesService) All(ctx context.Context, opts ...CallOption) iter.Seq2[*Capsule, error] {
+	return streamList[Capsule](ctx, s.client, "capsules", opts...)
 }
 
 // GetCapsule retrieves a specific capsule.
-func (s *CapsulesService) GetCapsule(ctx context.Context, id string) (*Capsule, error) {
+func (s *CapsulesService) GetCapsule(ctx context.Context, id string, opts ...CallOption) (*Capsule, *Response, error) {
 	u := fmt.Sprintf("capsules/%s", id)
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	capsule := new(Capsule)
-	_, err = s.client.do(ctx, req, capsule)
+	resp, err := s.client.do(ctx, req, capsule)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return capsule, nil
+	return capsule, resp, nil
+}
+
+// GetMany retrieves the capsules with the given IDs, in order. IDs that
+// don't exist have nil entries and are reported in a *MissingIDsError.
+func (s *CapsulesService) GetMany(ctx context.Context, ids []string, opts ...CallOption) ([]*Capsule, *Response, error) {
+	return getMany(ctx, s.client, "capsules", ids, func(c *Capsule) string { return c.ID }, opts)
+}
+
+// QueryCapsules queries for capsules. The query can be built with the query
+// package.
+func (s *CapsulesService) QueryCapsules(ctx context.Context, query map[string]interface{}, opts ...CallOption) (*CapsuleQueryResults, *Response, error) {
+	return queryPage[*Capsule](ctx, s.client, "capsules", query, opts)
+}
+
+// QueryCapsulesPopulated queries for capsules like QueryCapsules,
+// additionally populating their launches.
+func (s *CapsulesService) QueryCapsulesPopulated(ctx context.Context, q map[string]interface{}, opts ...CallOption) (*PopulatedCapsuleQueryResults, *Response, error) {
+	return queryPage[*PopulatedCapsule](ctx, s.client, "capsules", query.Query(q).Populate("launches"), opts)
 }
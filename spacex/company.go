@@ -1,6 +1,8 @@
 package spacex
 
-import "context"
+import (
+	"context"
+)
 
 // CompanyService handles communication with the company related
 // methods of the SpaceX API.
@@ -25,6 +27,14 @@ type Company struct {
 	Headquarters  *Headquarters `json:"headquarters"`
 	Links         *Links        `json:"links"`
 	Summary       string        `json:"summary"`
+
+	Document
+}
+
+// MarshalJSON implements json.Marshaler, including the fields in Extra.
+func (c Company) MarshalJSON() ([]byte, error) {
+	type company Company
+	return marshalModel(company(c), c.Extra)
 }
 
 // Headquarters represents the company headquarters.
@@ -43,18 +53,18 @@ type Links struct {
 }
 
 // GetCompanyInfo retrieves company information.
-func (s *CompanyService) GetCompanyInfo(ctx context.Context) (*Company, error) {
+func (s *CompanyService) GetCompanyInfo(ctx context.Context, opts ...CallOption) (*Company, *Response, error) {
 	u := "company"
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	company := new(Company)
-	_, err = s.client.do(ctx, req, company)
+	resp, err := s.client.do(ctx, req, company)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return company, nil
+	return company, resp, nil
 }
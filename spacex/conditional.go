@@ -0,0 +1,59 @@
+package spacex
+
+import (
+	"net/http"
+	"time"
+)
+
+// maxValidators bounds the number of requests whose validators are
+// remembered for conditional requests.
+const maxValidators = 1024
+
+// validatorTTL is how long validators are remembered without being used.
+const validatorTTL = 24 * time.Hour
+
+// validatorEntry is the last successful response to a GET request, along
+// with the validators needed to revalidate it.
+type validatorEntry struct {
+	ETag         string
+	LastModified string
+	Body         []byte
+}
+
+// validators returns the remembered entry for req, if conditional requests
+// are enabled and req is eligible. Entries are keyed like the cache, by URL
+// and the headers set with WithHeader.
+func (c *Client) validators(req *http.Request) (*validatorEntry, bool) {
+	if c.validatorStore == nil || req.Method != "GET" {
+		return nil, false
+	}
+	return c.validatorStore.get(c.requestKey(req))
+}
+
+// setConditionalHeaders adds If-None-Match and If-Modified-Since headers to
+// req from the remembered entry.
+func setConditionalHeaders(req *http.Request, entry *validatorEntry) {
+	if entry.ETag != "" {
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+	if entry.LastModified != "" {
+		req.Header.Set("If-Modified-Since", entry.LastModified)
+	}
+}
+
+// rememberValidators stores the validators and body of a successful GET
+// response so later identical requests can be revalidated.
+func (c *Client) rememberValidators(req *http.Request, resp *http.Response, body []byte) {
+	if c.validatorStore == nil || req.Method != "GET" {
+		return
+	}
+	entry := &validatorEntry{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Body:         body,
+	}
+	if entry.ETag == "" && entry.LastModified == "" {
+		return
+	}
+	c.validatorStore.set(c.requestKey(req), entry, validatorTTL)
+}
@@ -0,0 +1,72 @@
+package spacex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestDo_ConditionalRequests(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.EnableConditionalRequests()
+
+	calls := 0
+	mux.HandleFunc("/launches/next", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Wed, 01 Jan 2020 00:00:00 GMT")
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			if got := r.Header.Get("If-Modified-Since"); got != "Wed, 01 Jan 2020 00:00:00 GMT" {
+				t.Errorf("If-Modified-Since = %q", got)
+			}
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprint(w, `{"name":"Crew-9"}`)
+	})
+
+	ctx := context.Background()
+	launch, resp, err := client.Launches.GetNextLaunch(ctx)
+	if err != nil {
+		t.Fatalf("Launches.GetNextLaunch returned error: %v", err)
+	}
+	if resp.NotModified {
+		t.Errorf("first request: NotModified = true, want false")
+	}
+
+	launch, resp, err = client.Launches.GetNextLaunch(ctx)
+	if err != nil {
+		t.Fatalf("Launches.GetNextLaunch returned error: %v", err)
+	}
+	if !resp.NotModified {
+		t.Errorf("second request: NotModified = false, want true")
+	}
+	if launch.Name != "Crew-9" {
+		t.Errorf("Launches.GetNextLaunch returned %+v, want %+v", launch.Name, "Crew-9")
+	}
+	if calls != 2 {
+		t.Errorf("server saw %d calls, want 2", calls)
+	}
+}
+
+func TestDo_ConditionalRequestsDisabled(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/launches/upcoming", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			t.Errorf("If-None-Match sent without conditional requests enabled")
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `[]`)
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Launches.ListUpcomingLaunches(ctx); err != nil {
+			t.Fatalf("Launches.ListUpcomingLaunches returned error: %v", err)
+		}
+	}
+}
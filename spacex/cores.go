@@ -3,6 +3,9 @@ package spacex
 import (
 	"context"
 	"fmt"
+	"iter"
+
+	"github.com/catdevman/go-spacex/spacex/query"
 )
 
 // CoresService handles communication with the core related
@@ -23,38 +26,101 @@ type Core struct {
 	ASDSLandings int      `json:"asds_landings"`
 	LastUpdate   *string  `json:"last_update"`
 	Launches     []string `json:"launches"`
+	ID           string   `json:"id"`
+
+	Document
+}
+
+// MarshalJSON implements json.Marshaler, including the fields in Extra.
+func (c Core) MarshalJSON() ([]byte, error) {
+	type core Core
+	return marshalModel(core(c), c.Extra)
+}
+
+// PopulatedCore is a core whose launches may be populated with the launch
+// documents. The embedded Core holds the launch IDs.
+type PopulatedCore struct {
+	Core
+	Launches []Ref[Launch] `json:"launches"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting populated and
+// unpopulated launches.
+func (c *PopulatedCore) UnmarshalJSON(data []byte) error {
+	*c = PopulatedCore{}
+	refs := &struct {
+		Launches *[]Ref[Launch] `json:"launches"`
+	}{&c.Launches}
+	if err := unmarshalPopulated(data, &c.Core, refs, []string{"launches"}, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, including populated launches.
+func (c PopulatedCore) MarshalJSON() ([]byte, error) {
+	return marshalPopulated(c.Core, map[string]interface{}{"launches": c.Launches})
 }
 
+// CoreQueryResults represents the result of a core query.
+type CoreQueryResults = Page[*Core]
+
+// PopulatedCoreQueryResults represents the result of a populated core query.
+type PopulatedCoreQueryResults = Page[*PopulatedCore]
+
 // ListAllCores lists all cores.
-func (s *CoresService) ListAllCores(ctx context.Context) ([]*Core, error) {
+func (s *CoresService) ListAllCores(ctx context.Context, opts ...CallOption) ([]*Core, *Response, error) {
 	u := "cores"
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var cores []*Core
-	_, err = s.client.do(ctx, req, &cores)
+	resp, err := s.client.do(ctx, req, &cores)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return cores, nil
+	return cores, resp, nil
+}
+
+// All iterates over all cores, decoding them one at a time.
+func (s *CoresService) All(ctx context.Context, opts ...CallOption) iter.Seq2[*Core, error] {
+	return streamList[Core](ctx, s.client, "cores", opts...)
 }
 
 // GetCore retrieves a specific core.
-func (s *CoresService) GetCore(ctx context.Context, id string) (*Core, error) {
+func (s *CoresService) GetCore(ctx context.Context, id string, opts ...CallOption) (*Core, *Response, error) {
 	u := fmt.Sprintf("cores/%s", id)
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	core := new(Core)
-	_, err = s.client.do(ctx, req, core)
+	resp, err := s.client.do(ctx, req, core)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return core, nil
+	return core, resp, nil
+}
+
+// GetMany retrieves the cores with the given IDs, in order. IDs that don't
+// exist have nil entries and are reported in a *MissingIDsError.
+func (s *CoresService) GetMany(ctx context.Context, ids []string, opts ...CallOption) ([]*Core, *Response, error) {
+	return getMany(ctx, s.client, "cores", ids, func(c *Core) string { return c.ID }, opts)
+}
+
+// QueryCores queries for cores. The query can be built with the query
+// package.
+func (s *CoresService) QueryCores(ctx context.Context, query map[string]interface{}, opts ...CallOption) (*CoreQueryResults, *Response, error) {
+	return queryPage[*Core](ctx, s.client, "cores", query, opts)
+}
+
+// QueryCoresPopulated queries for cores like QueryCores, additionally
+// populating their launches.
+func (s *CoresService) QueryCoresPopulated(ctx context.Context, q map[string]interface{}, opts ...CallOption) (*PopulatedCoreQueryResults, *Response, error) {
+	return queryPage[*PopulatedCore](ctx, s.client, "cores", query.Query(q).Populate("launches"), opts)
 }
@@ -3,6 +3,7 @@ package spacex
 import (
 	"context"
 	"fmt"
+	"iter"
 )
 
 // CrewService handles communication with the crew related
@@ -19,38 +20,67 @@ type Crew struct {
 	Image     *string  `json:"image"`
 	Wikipedia *string  `json:"wikipedia"`
 	Launches  []string `json:"launches"`
+	ID        string   `json:"id"`
+
+	Document
+}
+
+// MarshalJSON implements json.Marshaler, including the fields in Extra.
+func (c Crew) MarshalJSON() ([]byte, error) {
+	type crew Crew
+	return marshalModel(crew(c), c.Extra)
 }
 
+// CrewQueryResults represents the result of a crew query.
+type CrewQueryResults = Page[*Crew]
+
 // ListAllCrew lists all crew members.
-func (s *CrewService) ListAllCrew(ctx context.Context) ([]*Crew, error) {
+func (s *CrewService) ListAllCrew(ctx context.Context, opts ...CallOption) ([]*Crew, *Response, error) {
 	u := "crew"
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var crew []*Crew
-	_, err = s.client.do(ctx, req, &crew)
+	resp, err := s.client.do(ctx, req, &crew)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return crew, nil
+	return crew, resp, nil
+}
+
+// All iterates over all crew members, decoding them one at a time.
+func (s *CrewService) All(ctx context.Context, opts ...CallOption) iter.Seq2[*Crew, error] {
+	return streamList[Crew](ctx, s.client, "crew", opts...)
 }
 
 // GetCrew retrieves a specific crew member.
-func (s *CrewService) GetCrew(ctx context.Context, id string) (*Crew, error) {
+func (s *CrewService) GetCrew(ctx context.Context, id string, opts ...CallOption) (*Crew, *Response, error) {
 	u := fmt.Sprintf("crew/%s", id)
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	crew := new(Crew)
-	_, err = s.client.do(ctx, req, crew)
+	resp, err := s.client.do(ctx, req, crew)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return crew, nil
+	return crew, resp, nil
+}
+
+// GetMany retrieves the crew members with the given IDs, in order. IDs that
+// don't exist have nil entries and are reported in a *MissingIDsError.
+func (s *CrewService) GetMany(ctx context.Context, ids []string, opts ...CallOption) ([]*Crew, *Response, error) {
+	return getMany(ctx, s.client, "crew", ids, func(c *Crew) string { return c.ID }, opts)
+}
+
+// QueryCrew queries for crew members. The query can be built with the query
+// package.
+func (s *CrewService) QueryCrew(ctx context.Context, query map[string]interface{}, opts ...CallOption) (*CrewQueryResults, *Response, error) {
+	return queryPage[*Crew](ctx, s.client, "crew", query, opts)
 }
@@ -3,6 +3,7 @@ package spacex
 import (
 	"context"
 	"fmt"
+	"iter"
 )
 
 // DragonsService handles communication with the dragon related
@@ -36,6 +37,14 @@ type Dragon struct {
 	Wikipedia          string              `json:"wikipedia"`
 	Description        string              `json:"description"`
 	ID                 string              `json:"id"`
+
+	Document
+}
+
+// MarshalJSON implements json.Marshaler, including the fields in Extra.
+func (d Dragon) MarshalJSON() ([]byte, error) {
+	type dragon Dragon
+	return marshalModel(dragon(d), d.Extra)
 }
 
 // HeatShield represents the heat shield of a dragon.
@@ -84,66 +93,55 @@ type Trunk struct {
 }
 
 // DragonQueryResults represents the result of a dragon query.
-type DragonQueryResults struct {
-	Docs          []*Dragon `json:"docs"`
-	TotalDocs     int       `json:"totalDocs"`
-	Limit         int       `json:"limit"`
-	TotalPages    int       `json:"totalPages"`
-	Page          int       `json:"page"`
-	PagingCounter int       `json:"pagingCounter"`
-	HasPrevPage   bool      `json:"hasPrevPage"`
-	HasNextPage   bool      `json:"hasNextPage"`
-	PrevPage      *int      `json:"prevPage"`
-	NextPage      *int      `json:"nextPage"`
-}
+type DragonQueryResults = Page[*Dragon]
 
 // ListAllDragons lists all dragons.
-func (s *DragonsService) ListAllDragons(ctx context.Context) ([]*Dragon, error) {
+func (s *DragonsService) ListAllDragons(ctx context.Context, opts ...CallOption) ([]*Dragon, *Response, error) {
 	u := "dragons"
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var dragons []*Dragon
-	_, err = s.client.do(ctx, req, &dragons)
+	resp, err := s.client.do(ctx, req, &dragons)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return dragons, nil
+	return dragons, resp, nil
+}
+
+// All iterates over all dragons, decoding them one at a time.
+func (s *DragonsService) All(ctx context.Context, opts ...CallOption) iter.Seq2[*Dragon, error] {
+	return streamList[Dragon](ctx, s.client, "dragons", opts...)
 }
 
 // GetDragon retrieves a specific dragon.
-func (s *DragonsService) GetDragon(ctx context.Context, id string) (*Dragon, error) {
+func (s *DragonsService) GetDragon(ctx context.Context, id string, opts ...CallOption) (*Dragon, *Response, error) {
 	u := fmt.Sprintf("dragons/%s", id)
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	dragon := new(Dragon)
-	_, err = s.client.do(ctx, req, dragon)
+	resp, err := s.client.do(ctx, req, dragon)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return dragon, nil
+	return dragon, resp, nil
 }
 
-// QueryDragons queries for dragons.
-func (s *DragonsService) QueryDragons(ctx context.Context, query map[string]interface{}) (*DragonQueryResults, error) {
-	u := "dragons/query"
-	req, err := s.client.newRequest(ctx, "POST", u, query)
-	if err != nil {
-		return nil, err
-	}
-
-	results := new(DragonQueryResults)
-	_, err = s.client.do(ctx, req, results)
-	if err != nil {
-		return nil, err
-	}
+// GetMany retrieves the dragons with the given IDs, in order. IDs that don't
+// exist have nil entries and are reported in a *MissingIDsError.
+func (s *DragonsService) GetMany(ctx context.Context, ids []string, opts ...CallOption) ([]*Dragon, *Response, error) {
+	return getMany(ctx, s.client, "dragons", ids, func(d *Dragon) string { return d.ID }, opts)
+}
 
-	return results, nil
+// QueryDragons queries for dragons. The query can be built with the query
+// package.
+func (s *DragonsService) QueryDragons(ctx context.Context, query map[string]interface{}, opts ...CallOption) (*DragonQueryResults, *Response, error) {
+	return queryPage[*Dragon](ctx, s.client, "dragons", query, opts)
 }
@@ -0,0 +1,345 @@
+package spacex
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DriftReport collects differences between the JSON returned by the API and
+// the models of this package, grouped by endpoint. It is safe for concurrent
+// use. See Client.EnableStrictDecoding.
+type DriftReport struct {
+	mu        sync.Mutex
+	endpoints map[string]*EndpointDrift
+}
+
+// EndpointDrift describes the schema drift observed on one endpoint. Field
+// paths are dotted JSON paths, with "[]" standing for array elements, such as
+// "docs[].links.youtube_id".
+type EndpointDrift struct {
+	// UnknownFields counts occurrences of fields the models don't declare.
+	UnknownFields map[string]int
+
+	// TypeMismatches maps fields whose JSON type doesn't match the model to
+	// a description of the mismatch.
+	TypeMismatches map[string]string
+}
+
+func newDriftReport() *DriftReport {
+	return &DriftReport{endpoints: make(map[string]*EndpointDrift)}
+}
+
+// Endpoints returns the sorted list of endpoints with drift.
+func (r *DriftReport) Endpoints() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	endpoints := make([]string, 0, len(r.endpoints))
+	for e := range r.endpoints {
+		endpoints = append(endpoints, e)
+	}
+	sort.Strings(endpoints)
+	return endpoints
+}
+
+// Drift returns a copy of the drift observed on endpoint.
+func (r *DriftReport) Drift(endpoint string) EndpointDrift {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d := EndpointDrift{
+		UnknownFields:  make(map[string]int),
+		TypeMismatches: make(map[string]string),
+	}
+	if e, ok := r.endpoints[endpoint]; ok {
+		for k, v := range e.UnknownFields {
+			d.UnknownFields[k] = v
+		}
+		for k, v := range e.TypeMismatches {
+			d.TypeMismatches[k] = v
+		}
+	}
+	return d
+}
+
+// Empty reports whether no drift has been observed.
+func (r *DriftReport) Empty() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.endpoints) == 0
+}
+
+// Reset discards all observed drift.
+func (r *DriftReport) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.endpoints = make(map[string]*EndpointDrift)
+}
+
+// String returns a human readable summary of the report, one line per
+// drifted field.
+func (r *DriftReport) String() string {
+	var b strings.Builder
+	for _, endpoint := range r.Endpoints() {
+		d := r.Drift(endpoint)
+		for _, field := range sortedKeys(d.UnknownFields) {
+			fmt.Fprintf(&b, "%s: unknown field %s (%d times)\n", endpoint, field, d.UnknownFields[field])
+		}
+		for _, field := range sortedKeys(d.TypeMismatches) {
+			fmt.Fprintf(&b, "%s: field %s: %s\n", endpoint, field, d.TypeMismatches[field])
+		}
+	}
+	return b.String()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// check compares the JSON document data with the Go type t and records any
+// drift under endpoint, prefixing field paths with path.
+func (r *DriftReport) check(endpoint, path string, data []byte, t reflect.Type) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return
+	}
+
+	w := &driftWalker{
+		unknown:    make(map[string]int),
+		mismatches: make(map[string]string),
+	}
+	w.walk(doc, t, path)
+	if len(w.unknown) == 0 && len(w.mismatches) == 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e, ok := r.endpoints[endpoint]
+	if !ok {
+		e = &EndpointDrift{
+			UnknownFields:  make(map[string]int),
+			TypeMismatches: make(map[string]string),
+		}
+		r.endpoints[endpoint] = e
+	}
+	for k, v := range w.unknown {
+		e.UnknownFields[k] += v
+	}
+	for k, v := range w.mismatches {
+		e.TypeMismatches[k] = v
+	}
+}
+
+type driftWalker struct {
+	unknown    map[string]int
+	mismatches map[string]string
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	rawMessageType      = reflect.TypeFor[json.RawMessage]()
+)
+
+func (w *driftWalker) walk(v interface{}, t reflect.Type, path string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if v == nil || t == rawMessageType || t.Kind() == reflect.Interface {
+		return
+	}
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) &&
+		(t.Kind() != reflect.Struct || len(jsonFields(t)) == 0) {
+		return // decodes itself in ways we can't predict
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			w.mismatch(path, "object", v)
+			return
+		}
+		fields := jsonFields(t)
+		for key, val := range obj {
+			p := joinPath(path, key)
+			ft, ok := fields[key]
+			if !ok {
+				w.unknown[p]++
+				continue
+			}
+			w.walk(val, ft, p)
+		}
+	case reflect.Slice, reflect.Array:
+		arr, ok := v.([]interface{})
+		if !ok {
+			w.mismatch(path, "array", v)
+			return
+		}
+		for _, el := range arr {
+			w.walk(el, t.Elem(), path+"[]")
+		}
+	case reflect.Map:
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			w.mismatch(path, "object", v)
+			return
+		}
+		for key, val := range obj {
+			w.walk(val, t.Elem(), joinPath(path, key))
+		}
+	case reflect.String:
+		if _, ok := v.(string); !ok {
+			w.mismatch(path, "string", v)
+		}
+	case reflect.Bool:
+		if _, ok := v.(bool); !ok {
+			w.mismatch(path, "boolean", v)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := v.(json.Number)
+		if !ok {
+			w.mismatch(path, "integer", v)
+		} else if _, err := n.Int64(); err != nil {
+			w.mismatches[path] = fmt.Sprintf("want integer, got %s", n)
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := v.(json.Number); !ok {
+			w.mismatch(path, "number", v)
+		}
+	}
+}
+
+func (w *driftWalker) mismatch(path, want string, got interface{}) {
+	if path == "" {
+		path = "."
+	}
+	w.mismatches[path] = fmt.Sprintf("want %s, got %s", want, jsonTypeName(got))
+}
+
+func jsonTypeName(v interface{}) string {
+	switch v.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	}
+	return "null"
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// jsonFieldsCache maps struct types to their JSON-visible fields.
+var jsonFieldsCache sync.Map
+
+// jsonFields returns the JSON-visible fields of struct type t by name. The
+// result is cached and must not be modified.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	if f, ok := jsonFieldsCache.Load(t); ok {
+		return f.(map[string]reflect.Type)
+	}
+
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, ok := jsonName(f)
+		if !ok {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for k, v := range jsonFields(ft) {
+					fields[k] = v
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+
+	f, _ := jsonFieldsCache.LoadOrStore(t, fields)
+	return f.(map[string]reflect.Type)
+}
+
+// jsonName returns the name given to field f by its json tag, which is empty
+// if the tag sets none. It reports false if f is left out of the JSON
+// encoding.
+func jsonName(f reflect.StructField) (string, bool) {
+	if !f.IsExported() && !f.Anonymous {
+		return "", false
+	}
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	return name, true
+}
+
+// strictDecode decodes data, found at path in the response body, into v,
+// recording drift under endpoint. Type mismatches are reported in the drift
+// report rather than as errors, the remaining fields being decoded as usual.
+func (c *Client) strictDecode(endpoint, path string, data []byte, v interface{}) error {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil // ignore empty response bodies, like decodeBody
+	}
+	c.driftReport.check(endpoint, path, data, reflect.TypeOf(v))
+
+	err := json.Unmarshal(data, v)
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return nil
+	}
+	return err
+}
+
+// EnableStrictDecoding makes the client check every decoded response
+// against its model and record unknown fields and type mismatches in the
+// report returned by DriftReport. In this mode type mismatches no longer
+// fail the call.
+func (c *Client) EnableStrictDecoding() {
+	if c.driftReport == nil {
+		c.driftReport = newDriftReport()
+	}
+}
+
+// DriftReport returns the schema drift observed since strict decoding was
+// enabled, or nil if it is disabled.
+func (c *Client) DriftReport() *DriftReport {
+	return c.driftReport
+}
@@ -0,0 +1,94 @@
+package spacex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClient_StrictDecoding(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.EnableStrictDecoding()
+
+	mux.HandleFunc("/launchpads/5e9e4502f5090995de566f86", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"Kwajalein Atoll","images":{"large":["a.png"]},"launch_attempts":"5","latitude":9.04}`)
+	})
+
+	ctx := context.Background()
+	launchpad, _, err := client.Launchpads.GetLaunchpad(ctx, "5e9e4502f5090995de566f86")
+	if err != nil {
+		t.Fatalf("Launchpads.GetLaunchpad returned error: %v", err)
+	}
+	if *launchpad.Name != "Kwajalein Atoll" || *launchpad.Latitude != 9.04 {
+		t.Errorf("Launchpads.GetLaunchpad returned %+v, want remaining fields decoded", launchpad)
+	}
+
+	report := client.DriftReport()
+	if got, want := report.Endpoints(), []string{"launchpads/{id}"}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("Endpoints() = %v, want %v", got, want)
+	}
+	d := report.Drift("launchpads/{id}")
+	if d.UnknownFields["images"] != 1 {
+		t.Errorf("UnknownFields = %v, want images", d.UnknownFields)
+	}
+	if got, want := d.TypeMismatches["launch_attempts"], "want integer, got string"; got != want {
+		t.Errorf("TypeMismatches[launch_attempts] = %q, want %q", got, want)
+	}
+	if !strings.Contains(report.String(), "launchpads/{id}: unknown field images") {
+		t.Errorf("String() = %q", report.String())
+	}
+
+	report.Reset()
+	if !report.Empty() {
+		t.Errorf("Empty() = false after Reset")
+	}
+}
+
+func TestClient_StrictDecodingNested(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.EnableStrictDecoding()
+
+	mux.HandleFunc("/launches/query", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"docs":[{"name":"A","links":{"youtube_id":"x","podcast":"y"}},{"name":"B","links":{"podcast":"z"}}],"offset":0}`)
+	})
+	mux.HandleFunc("/cores", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"serial":"B1051","water_landing":true}]`)
+	})
+
+	ctx := context.Background()
+	if _, _, err := client.Launches.QueryLaunches(ctx, map[string]interface{}{}); err != nil {
+		t.Fatalf("Launches.QueryLaunches returned error: %v", err)
+	}
+	for _, err := range client.Cores.All(ctx) {
+		if err != nil {
+			t.Fatalf("Cores.All returned error: %v", err)
+		}
+	}
+
+	report := client.DriftReport()
+	d := report.Drift("launches/query")
+	if d.UnknownFields["docs[].links.podcast"] != 2 || d.UnknownFields["offset"] != 1 {
+		t.Errorf("launches/query UnknownFields = %v", d.UnknownFields)
+	}
+	if len(d.TypeMismatches) != 0 {
+		t.Errorf("launches/query TypeMismatches = %v, want none", d.TypeMismatches)
+	}
+	if _, _, err := client.Cores.ListAllCores(ctx); err != nil {
+		t.Fatalf("Cores.ListAllCores returned error: %v", err)
+	}
+	// Streamed and listed elements are reported under the same path.
+	if d := report.Drift("cores"); d.UnknownFields["[].water_landing"] != 2 || len(d.UnknownFields) != 1 {
+		t.Errorf("cores UnknownFields = %v, want [].water_landing twice", d.UnknownFields)
+	}
+}
+
+func TestClient_DriftReportDisabled(t *testing.T) {
+	client := NewClient(nil)
+	if client.DriftReport() != nil {
+		t.Errorf("DriftReport() = non-nil without strict decoding")
+	}
+}
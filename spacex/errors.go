@@ -0,0 +1,109 @@
+package spacex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors that an *ErrorResponse matches with errors.Is, based on
+// the HTTP status code of the response.
+var (
+	ErrBadRequest   = errors.New("spacex: bad request")
+	ErrUnauthorized = errors.New("spacex: unauthorized")
+	ErrForbidden    = errors.New("spacex: forbidden")
+	ErrNotFound     = errors.New("spacex: not found")
+	ErrRateLimited  = errors.New("spacex: rate limited")
+	ErrServer       = errors.New("spacex: server error")
+)
+
+// ErrorResponse reports an error caused by an API request that returned a
+// non-2xx status code.
+type ErrorResponse struct {
+	Response   *http.Response // HTTP response that caused this error
+	StatusCode int            // HTTP status code of the response
+	Method     string         // HTTP method of the request
+	URL        string         // URL of the request
+	Body       []byte         // raw response body
+	Message    string         // error message parsed from the body, if any
+}
+
+func (r *ErrorResponse) Error() string {
+	msg := r.Message
+	if msg == "" {
+		msg = http.StatusText(r.StatusCode)
+	}
+	return fmt.Sprintf("%v %v: %d %v", r.Method, r.URL, r.StatusCode, msg)
+}
+
+// Is reports whether the error matches one of the package sentinel errors.
+func (r *ErrorResponse) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return r.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return r.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return r.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return r.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return r.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return r.StatusCode >= 500 && r.StatusCode <= 599
+	}
+	return false
+}
+
+// checkResponse checks the API response for errors, and returns them if
+// present. A response is considered an error if it has a status code outside
+// the 200 range. The response body is consumed but not closed.
+func checkResponse(r *http.Response) error {
+	if c := r.StatusCode; 200 <= c && c <= 299 {
+		return nil
+	}
+
+	errorResponse := &ErrorResponse{
+		Response:   r,
+		StatusCode: r.StatusCode,
+	}
+	if r.Request != nil {
+		errorResponse.Method = r.Request.Method
+		errorResponse.URL = r.Request.URL.String()
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read error response body: %w", err)
+	}
+	errorResponse.Body = data
+	errorResponse.Message = parseErrorMessage(data)
+
+	return errorResponse
+}
+
+// parseErrorMessage extracts a human readable message from an error body.
+// The API answers with either a JSON document or a plain text status.
+func parseErrorMessage(data []byte) string {
+	var v struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(data, &v); err == nil {
+		if v.Message != "" {
+			return v.Message
+		}
+		if v.Error != "" {
+			return v.Error
+		}
+	}
+
+	msg := strings.TrimSpace(string(data))
+	if strings.HasPrefix(msg, "{") || strings.HasPrefix(msg, "<") {
+		return ""
+	}
+	return msg
+}
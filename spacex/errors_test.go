@@ -0,0 +1,68 @@
+package spacex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestDo_ErrorResponse(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/launches/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":"Launch not found"}`)
+	})
+
+	ctx := context.Background()
+	_, _, err := client.Launches.GetLaunch(ctx, "missing")
+	if err == nil {
+		t.Fatal("Launches.GetLaunch returned no error, want 404")
+	}
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("errors.Is(err, ErrNotFound) = false, want true")
+	}
+	if errors.Is(err, ErrServer) {
+		t.Errorf("errors.Is(err, ErrServer) = true, want false")
+	}
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("error is %T, want *ErrorResponse", err)
+	}
+	if errResp.StatusCode != http.StatusNotFound {
+		t.Errorf("StatusCode = %d, want %d", errResp.StatusCode, http.StatusNotFound)
+	}
+	if errResp.Method != "GET" {
+		t.Errorf("Method = %q, want %q", errResp.Method, "GET")
+	}
+	if errResp.Message != "Launch not found" {
+		t.Errorf("Message = %q, want %q", errResp.Message, "Launch not found")
+	}
+}
+
+func TestErrorResponse_Is(t *testing.T) {
+	tests := []struct {
+		status int
+		target error
+		want   bool
+	}{
+		{http.StatusBadRequest, ErrBadRequest, true},
+		{http.StatusNotFound, ErrNotFound, true},
+		{http.StatusTooManyRequests, ErrRateLimited, true},
+		{http.StatusInternalServerError, ErrServer, true},
+		{http.StatusBadGateway, ErrServer, true},
+		{http.StatusNotFound, ErrServer, false},
+	}
+
+	for _, tt := range tests {
+		err := &ErrorResponse{StatusCode: tt.status}
+		if got := errors.Is(err, tt.target); got != tt.want {
+			t.Errorf("errors.Is(%d, %v) = %v, want %v", tt.status, tt.target, got, tt.want)
+		}
+	}
+}
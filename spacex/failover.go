@@ -0,0 +1,185 @@
+package spacex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultProbeInterval is how long a failed mirror is skipped before a
+// request is sent to it again.
+const defaultProbeInterval = time.Minute
+
+// MirrorStatus describes the health of one of the base URLs of a Client
+// configured for failover.
+type MirrorStatus struct {
+	URL       string
+	Healthy   bool
+	DownSince time.Time // zero if healthy
+}
+
+// mirrorSet tracks the health of an ordered list of base URLs, the first
+// being the primary.
+type mirrorSet struct {
+	probeInterval time.Duration
+
+	mu        sync.Mutex
+	urls      []*url.URL
+	downSince []time.Time
+}
+
+// order returns the indexes of the mirrors to try, in order. Healthy mirrors
+// come first, as well as failed mirrors due for a probe, so that the client
+// fails back to the primary once it recovers. Recently failed mirrors are
+// kept as a last resort.
+func (m *mirrorSet) order() []int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var first, last []int
+	for i, since := range m.downSince {
+		if since.IsZero() || time.Since(since) >= m.probeInterval {
+			first = append(first, i)
+		} else {
+			last = append(last, i)
+		}
+	}
+	return append(first, last...)
+}
+
+func (m *mirrorSet) markUp(i int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.downSince[i] = time.Time{}
+}
+
+func (m *mirrorSet) markDown(i int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// Restart the probe interval, so a failed probe is not retried at once.
+	m.downSince[i] = time.Now()
+}
+
+// SetFailover configures the client to send requests to the first of
+// baseURLs and fail over to the next ones, in order, on connection errors and
+// 5xx responses. Failed base URLs are skipped for probeInterval, after which
+// a request is sent to them again so the client fails back once they
+// recover. A zero probeInterval defaults to one minute. The first base URL
+// replaces BaseURL.
+func (c *Client) SetFailover(probeInterval time.Duration, baseURLs ...string) error {
+	if len(baseURLs) == 0 {
+		return errors.New("at least one base URL is required")
+	}
+	if probeInterval < 0 {
+		return fmt.Errorf("probe interval must not be negative, got %v", probeInterval)
+	}
+	if probeInterval == 0 {
+		probeInterval = defaultProbeInterval
+	}
+
+	m := &mirrorSet{
+		probeInterval: probeInterval,
+		downSince:     make([]time.Time, len(baseURLs)),
+	}
+	for _, s := range baseURLs {
+		u, err := url.Parse(s)
+		if err != nil {
+			return fmt.Errorf("invalid base URL: %w", err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("base URL %q must be absolute", s)
+		}
+		if !strings.HasSuffix(u.Path, "/") {
+			return fmt.Errorf("baseURL must have a trailing slash, but %q does not", s)
+		}
+		m.urls = append(m.urls, u)
+	}
+
+	c.BaseURL = m.urls[0]
+	c.mirrors = m
+	return nil
+}
+
+// MirrorStatus returns the health of each base URL configured with
+// SetFailover, in order, or nil if failover is not configured.
+func (c *Client) MirrorStatus() []MirrorStatus {
+	if c.mirrors == nil {
+		return nil
+	}
+	m := c.mirrors
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	statuses := make([]MirrorStatus, len(m.urls))
+	for i, u := range m.urls {
+		statuses[i] = MirrorStatus{
+			URL:       u.String(),
+			Healthy:   m.downSince[i].IsZero(),
+			DownSince: m.downSince[i],
+		}
+	}
+	return statuses
+}
+
+// sendFailover sends req to the configured mirrors in turn until one
+// answers without a connection error or 5xx status.
+func (c *Client) sendFailover(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if c.mirrors == nil {
+		return c.sendAllowed(ctx, req)
+	}
+
+	var (
+		resp *http.Response
+		err  error
+	)
+	for n, i := range c.mirrors.order() {
+		if n > 0 {
+			if resp != nil {
+				io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+			}
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return nil, err
+			}
+		}
+
+		r := req.Clone(ctx)
+		r.URL = rebaseURL(req.URL, c.BaseURL, c.mirrors.urls[i])
+		r.Host = ""
+		if n > 0 && req.GetBody != nil {
+			if r.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err = c.sendAllowed(ctx, r)
+		if err == nil && resp.StatusCode < 500 {
+			c.mirrors.markUp(i)
+			return resp, nil
+		}
+		if ctx.Err() == nil {
+			c.mirrors.markDown(i)
+		}
+	}
+	return resp, err
+}
+
+// rebaseURL moves u, a URL below from, to the same path below to. API
+// version segments are preserved, so that a request for v5 is sent to the
+// v5 API of the mirror.
+func rebaseURL(u, from, to *url.URL) *url.URL {
+	rebased := *u
+	rebased.Scheme = to.Scheme
+	rebased.Host = to.Host
+	rebased.User = to.User
+	rel := strings.TrimPrefix(u.Path, withoutVersion(from.Path))
+	rebased.Path = withoutVersion(to.Path) + rel
+	rebased.RawPath = ""
+	return &rebased
+}
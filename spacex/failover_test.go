@@ -0,0 +1,89 @@
+package spacex
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_Failover(t *testing.T) {
+	primaryUp := false
+	var primaryCalls, mirrorCalls int
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryCalls++
+		if !primaryUp {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"docs":[{"name":"primary"}]}`)
+	}))
+	defer primary.Close()
+
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mirrorCalls++
+		if r.URL.Path != "/api/v4/launches/query" {
+			t.Errorf("mirror request path = %q, want %q", r.URL.Path, "/api/v4/launches/query")
+		}
+		if body, _ := io.ReadAll(r.Body); string(body) != "{\"query\":{}}\n" {
+			t.Errorf("mirror request body = %q", body)
+		}
+		fmt.Fprint(w, `{"docs":[{"name":"mirror"}]}`)
+	}))
+	defer mirror.Close()
+
+	client, err := NewClientWithOptions(WithFailover(50*time.Millisecond, primary.URL+"/v4/", mirror.URL+"/api/v4/"))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions returned error: %v", err)
+	}
+
+	ctx := context.Background()
+	q := map[string]interface{}{"query": map[string]interface{}{}}
+	query := func() string {
+		results, _, err := client.Launches.QueryLaunches(ctx, q)
+		if err != nil {
+			t.Fatalf("Launches.QueryLaunches returned error: %v", err)
+		}
+		return results.Docs[0].Name
+	}
+
+	if got := query(); got != "mirror" {
+		t.Errorf("first query served by %q, want mirror", got)
+	}
+	if status := client.MirrorStatus(); status[0].Healthy || !status[1].Healthy {
+		t.Errorf("MirrorStatus() = %+v, want primary down", status)
+	}
+
+	// The failed primary is skipped until the probe interval elapses.
+	if got := query(); got != "mirror" {
+		t.Errorf("second query served by %q, want mirror", got)
+	}
+	if primaryCalls != 1 {
+		t.Errorf("primary saw %d calls, want 1", primaryCalls)
+	}
+
+	primaryUp = true
+	time.Sleep(60 * time.Millisecond)
+	if got := query(); got != "primary" {
+		t.Errorf("query after probe interval served by %q, want primary", got)
+	}
+	if status := client.MirrorStatus(); !status[0].Healthy {
+		t.Errorf("MirrorStatus() = %+v, want primary healthy", status)
+	}
+	if mirrorCalls != 2 {
+		t.Errorf("mirror saw %d calls, want 2", mirrorCalls)
+	}
+}
+
+func TestClient_SetFailoverInvalid(t *testing.T) {
+	client := NewClient(nil)
+	if err := client.SetFailover(0); err == nil {
+		t.Errorf("SetFailover with no URLs returned no error")
+	}
+	if err := client.SetFailover(0, "https://api.spacexdata.com/v4"); err == nil {
+		t.Errorf("SetFailover without trailing slash returned no error")
+	}
+}
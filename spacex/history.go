@@ -3,6 +3,7 @@ package spacex
 import (
 	"context"
 	"fmt"
+	"iter"
 )
 
 // HistoryService handles communication with the history related
@@ -20,38 +21,67 @@ type History struct {
 	Links         *struct {
 		Article *string `json:"article"`
 	} `json:"links"`
+	ID string `json:"id"`
+
+	Document
+}
+
+// MarshalJSON implements json.Marshaler, including the fields in Extra.
+func (h History) MarshalJSON() ([]byte, error) {
+	type history History
+	return marshalModel(history(h), h.Extra)
 }
 
+// HistoryQueryResults represents the result of a history query.
+type HistoryQueryResults = Page[*History]
+
 // ListAllHistory lists all history events.
-func (s *HistoryService) ListAllHistory(ctx context.Context) ([]*History, error) {
+func (s *HistoryService) ListAllHistory(ctx context.Context, opts ...CallOption) ([]*History, *Response, error) {
 	u := "history"
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var history []*History
-	_, err = s.client.do(ctx, req, &history)
+	resp, err := s.client.do(ctx, req, &history)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return history, nil
+	return history, resp, nil
+}
+
+// All iterates over all history events, decoding them one at a time.
+func (s *HistoryService) All(ctx context.Context, opts ...CallOption) iter.Seq2[*History, error] {
+	return streamList[History](ctx, s.client, "history", opts...)
 }
 
 // GetHistory retrieves a specific history event.
-func (s *HistoryService) GetHistory(ctx context.Context, id string) (*History, error) {
+func (s *HistoryService) GetHistory(ctx context.Context, id string, opts ...CallOption) (*History, *Response, error) {
 	u := fmt.Sprintf("history/%s", id)
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	history := new(History)
-	_, err = s.client.do(ctx, req, history)
+	resp, err := s.client.do(ctx, req, history)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return history, nil
+	return history, resp, nil
+}
+
+// GetMany retrieves the history events with the given IDs, in order. IDs
+// that don't exist have nil entries and are reported in a *MissingIDsError.
+func (s *HistoryService) GetMany(ctx context.Context, ids []string, opts ...CallOption) ([]*History, *Response, error) {
+	return getMany(ctx, s.client, "history", ids, func(h *History) string { return h.ID }, opts)
+}
+
+// QueryHistory queries for history events. The query can be built with the
+// query package.
+func (s *HistoryService) QueryHistory(ctx context.Context, query map[string]interface{}, opts ...CallOption) (*HistoryQueryResults, *Response, error) {
+	return queryPage[*History](ctx, s.client, "history", query, opts)
 }
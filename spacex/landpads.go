@@ -3,6 +3,7 @@ package spacex
 import (
 	"context"
 	"fmt"
+	"iter"
 )
 
 // LandpadsService handles communication with the landpad related
@@ -26,38 +27,67 @@ type Landpad struct {
 	Wikipedia        *string  `json:"wikipedia"`
 	Details          *string  `json:"details"`
 	Launches         []string `json:"launches"`
+	ID               string   `json:"id"`
+
+	Document
+}
+
+// MarshalJSON implements json.Marshaler, including the fields in Extra.
+func (l Landpad) MarshalJSON() ([]byte, error) {
+	type landpad Landpad
+	return marshalModel(landpad(l), l.Extra)
 }
 
+// LandpadQueryResults represents the result of a landpad query.
+type LandpadQueryResults = Page[*Landpad]
+
 // ListAllLandpads lists all landpads.
-func (s *LandpadsService) ListAllLandpads(ctx context.Context) ([]*Landpad, error) {
+func (s *LandpadsService) ListAllLandpads(ctx context.Context, opts ...CallOption) ([]*Landpad, *Response, error) {
 	u := "landpads"
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var landpads []*Landpad
-	_, err = s.client.do(ctx, req, &landpads)
+	resp, err := s.client.do(ctx, req, &landpads)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return landpads, nil
+	return landpads, resp, nil
+}
+
+// All iterates over all landpads, decoding them one at a time.
+func (s *LandpadsService) All(ctx context.Context, opts ...CallOption) iter.Seq2[*Landpad, error] {
+	return streamList[Landpad](ctx, s.client, "landpads", opts...)
 }
 
 // GetLandpad retrieves a specific landpad.
-func (s *LandpadsService) GetLandpad(ctx context.Context, id string) (*Landpad, error) {
+func (s *LandpadsService) GetLandpad(ctx context.Context, id string, opts ...CallOption) (*Landpad, *Response, error) {
 	u := fmt.Sprintf("landpads/%s", id)
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	landpad := new(Landpad)
-	_, err = s.client.do(ctx, req, landpad)
+	resp, err := s.client.do(ctx, req, landpad)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return landpad, nil
+	return landpad, resp, nil
+}
+
+// GetMany retrieves the landpads with the given IDs, in order. IDs that
+// don't exist have nil entries and are reported in a *MissingIDsError.
+func (s *LandpadsService) GetMany(ctx context.Context, ids []string, opts ...CallOption) ([]*Landpad, *Response, error) {
+	return getMany(ctx, s.client, "landpads", ids, func(l *Landpad) string { return l.ID }, opts)
+}
+
+// QueryLandpads queries for landpads. The query can be built with the query
+// package.
+func (s *LandpadsService) QueryLandpads(ctx context.Context, query map[string]interface{}, opts ...CallOption) (*LandpadQueryResults, *Response, error) {
+	return queryPage[*Landpad](ctx, s.client, "landpads", query, opts)
 }
@@ -3,6 +3,9 @@ package spacex
 import (
 	"context"
 	"fmt"
+	"iter"
+
+	"github.com/catdevman/go-spacex/spacex/query"
 )
 
 // LaunchesService handles communication with the launch related
@@ -39,6 +42,14 @@ type Launch struct {
 	Links              *LaunchLinks  `json:"links"`
 	AutoUpdate         bool          `json:"auto_update"`
 	ID                 string        `json:"id"`
+
+	Document
+}
+
+// MarshalJSON implements json.Marshaler, including the fields in Extra.
+func (l Launch) MarshalJSON() ([]byte, error) {
+	type launch Launch
+	return marshalModel(launch(l), l.Extra)
 }
 
 // Failure represents a launch failure.
@@ -69,6 +80,74 @@ type CoreLaunch struct {
 	Landpad        *string `json:"landpad"`
 }
 
+// launchRefs are the reference fields of a launch, as populate paths.
+var launchRefs = []string{"rocket", "launchpad", "payloads", "crew", "capsules", "ships", "cores.core", "cores.landpad"}
+
+// PopulatedLaunch is a launch whose references may be populated with the
+// documents they refer to. The embedded Launch holds the IDs of all
+// references.
+type PopulatedLaunch struct {
+	Launch
+	Rocket    Ref[Rocket]            `json:"rocket"`
+	Launchpad Ref[Launchpad]         `json:"launchpad"`
+	Payloads  []Ref[Payload]         `json:"payloads"`
+	Crew      []Ref[Crew]            `json:"crew"`
+	Capsules  []Ref[Capsule]         `json:"capsules"`
+	Ships     []Ref[Ship]            `json:"ships"`
+	Cores     []*PopulatedCoreLaunch `json:"cores"`
+}
+
+// PopulatedCoreLaunch is a core used in a launch whose core and landpad may
+// be populated.
+type PopulatedCoreLaunch struct {
+	CoreLaunch
+	Core    Ref[Core]    `json:"core"`
+	Landpad Ref[Landpad] `json:"landpad"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting populated and
+// unpopulated references.
+func (l *PopulatedLaunch) UnmarshalJSON(data []byte) error {
+	*l = PopulatedLaunch{}
+	refs := &struct {
+		Rocket    *Ref[Rocket]            `json:"rocket"`
+		Launchpad *Ref[Launchpad]         `json:"launchpad"`
+		Payloads  *[]Ref[Payload]         `json:"payloads"`
+		Crew      *[]Ref[Crew]            `json:"crew"`
+		Capsules  *[]Ref[Capsule]         `json:"capsules"`
+		Ships     *[]Ref[Ship]            `json:"ships"`
+		Cores     *[]*PopulatedCoreLaunch `json:"cores"`
+	}{&l.Rocket, &l.Launchpad, &l.Payloads, &l.Crew, &l.Capsules, &l.Ships, &l.Cores}
+	err := unmarshalPopulated(data, &l.Launch, refs,
+		[]string{"rocket", "launchpad", "payloads", "crew", "capsules", "ships"},
+		map[string][]string{"cores": {"core", "landpad"}})
+	if err != nil {
+		return err
+	}
+
+	// The embedded CoreLaunch fields shadowed by the references weren't
+	// decoded; take them from the embedded Launch.
+	for i, c := range l.Cores {
+		if c != nil && i < len(l.Launch.Cores) && l.Launch.Cores[i] != nil {
+			c.CoreLaunch = *l.Launch.Cores[i]
+		}
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, including populated documents.
+func (l PopulatedLaunch) MarshalJSON() ([]byte, error) {
+	return marshalPopulated(l.Launch, map[string]interface{}{
+		"rocket":    l.Rocket,
+		"launchpad": l.Launchpad,
+		"payloads":  l.Payloads,
+		"crew":      l.Crew,
+		"capsules":  l.Capsules,
+		"ships":     l.Ships,
+		"cores":     l.Cores,
+	})
+}
+
 // LaunchLinks represents links related to a launch.
 type LaunchLinks struct {
 	Patch     *Patch  `json:"patch"`
@@ -102,134 +181,133 @@ type Flickr struct {
 }
 
 // LaunchQueryResults represents the result of a launch query.
-type LaunchQueryResults struct {
-	Docs          []*Launch `json:"docs"`
-	TotalDocs     int       `json:"totalDocs"`
-	Limit         int       `json:"limit"`
-	TotalPages    int       `json:"totalPages"`
-	Page          int       `json:"page"`
-	PagingCounter int       `json:"pagingCounter"`
-	HasPrevPage   bool      `json:"hasPrevPage"`
-	HasNextPage   bool      `json:"hasNextPage"`
-	PrevPage      *int      `json:"prevPage"`
-	NextPage      *int      `json:"nextPage"`
-}
+type LaunchQueryResults = Page[*Launch]
+
+// PopulatedLaunchQueryResults represents the result of a populated launch query.
+type PopulatedLaunchQueryResults = Page[*PopulatedLaunch]
 
 // ListAllLaunches lists all launches.
-func (s *LaunchesService) ListAllLaunches(ctx context.Context) ([]*Launch, error) {
+func (s *LaunchesService) ListAllLaunches(ctx context.Context, opts ...CallOption) ([]*Launch, *Response, error) {
 	u := "launches"
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var launches []*Launch
-	_, err = s.client.do(ctx, req, &launches)
+	resp, err := s.client.do(ctx, req, &launches)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return launches, nil
+	return launches, resp, nil
+}
+
+// All iterates over all launches, decoding them one at a time.
+func (s *LaunchesService) All(ctx context.Context, opts ...CallOption) iter.Seq2[*Launch, error] {
+	return streamList[Launch](ctx, s.client, "launches", opts...)
 }
 
 // GetLaunch retrieves a specific launch.
-func (s *LaunchesService) GetLaunch(ctx context.Context, id string) (*Launch, error) {
+func (s *LaunchesService) GetLaunch(ctx context.Context, id string, opts ...CallOption) (*Launch, *Response, error) {
 	u := fmt.Sprintf("launches/%s", id)
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	launch := new(Launch)
-	_, err = s.client.do(ctx, req, launch)
+	resp, err := s.client.do(ctx, req, launch)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return launch, nil
+	return launch, resp, nil
+}
+
+// GetMany retrieves the launches with the given IDs, in order. IDs that
+// don't exist have nil entries and are reported in a *MissingIDsError.
+func (s *LaunchesService) GetMany(ctx context.Context, ids []string, opts ...CallOption) ([]*Launch, *Response, error) {
+	return getMany(ctx, s.client, "launches", ids, func(l *Launch) string { return l.ID }, opts)
 }
 
 // GetLatestLaunch retrieves the latest launch.
-func (s *LaunchesService) GetLatestLaunch(ctx context.Context) (*Launch, error) {
+func (s *LaunchesService) GetLatestLaunch(ctx context.Context, opts ...CallOption) (*Launch, *Response, error) {
 	u := "launches/latest"
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	launch := new(Launch)
-	_, err = s.client.do(ctx, req, launch)
+	resp, err := s.client.do(ctx, req, launch)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return launch, nil
+	return launch, resp, nil
 }
 
 // GetNextLaunch retrieves the next launch.
-func (s *LaunchesService) GetNextLaunch(ctx context.Context) (*Launch, error) {
+func (s *LaunchesService) GetNextLaunch(ctx context.Context, opts ...CallOption) (*Launch, *Response, error) {
 	u := "launches/next"
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	launch := new(Launch)
-	_, err = s.client.do(ctx, req, launch)
+	resp, err := s.client.do(ctx, req, launch)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return launch, nil
+	return launch, resp, nil
 }
 
 // ListPastLaunches lists past launches.
-func (s *LaunchesService) ListPastLaunches(ctx context.Context) ([]*Launch, error) {
+func (s *LaunchesService) ListPastLaunches(ctx context.Context, opts ...CallOption) ([]*Launch, *Response, error) {
 	u := "launches/past"
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var launches []*Launch
-	_, err = s.client.do(ctx, req, &launches)
+	resp, err := s.client.do(ctx, req, &launches)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return launches, nil
+	return launches, resp, nil
 }
 
 // ListUpcomingLaunches lists upcoming launches.
-func (s *LaunchesService) ListUpcomingLaunches(ctx context.Context) ([]*Launch, error) {
+func (s *LaunchesService) ListUpcomingLaunches(ctx context.Context, opts ...CallOption) ([]*Launch, *Response, error) {
 	u := "launches/upcoming"
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var launches []*Launch
-	_, err = s.client.do(ctx, req, &launches)
+	resp, err := s.client.do(ctx, req, &launches)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return launches, nil
+	return launches, resp, nil
 }
 
-// QueryLaunches queries for launches.
-func (s *LaunchesService) QueryLaunches(ctx context.Context, query map[string]interface{}) (*LaunchQueryResults, error) {
-	u := "launches/query"
-	req, err := s.client.newRequest(ctx, "POST", u, query)
-	if err != nil {
-		return nil, err
-	}
-
-	results := new(LaunchQueryResults)
-	_, err = s.client.do(ctx, req, results)
-	if err != nil {
-		return nil, err
-	}
+// QueryLaunches queries for launches. The query can be built with the query
+// package.
+func (s *LaunchesService) QueryLaunches(ctx context.Context, query map[string]interface{}, opts ...CallOption) (*LaunchQueryResults, *Response, error) {
+	return queryPage[*Launch](ctx, s.client, "launches", query, opts)
+}
 
-	return results, nil
+// QueryLaunchesPopulated queries for launches like QueryLaunches,
+// additionally populating the rocket, launchpad, payloads, crew, capsules,
+// ships and the core and landpad of every core.
+func (s *LaunchesService) QueryLaunchesPopulated(ctx context.Context, q map[string]interface{}, opts ...CallOption) (*PopulatedLaunchQueryResults, *Response, error) {
+	return queryPage[*PopulatedLaunch](ctx, s.client, "launches", query.Query(q).Populate(launchRefs...), opts)
 }
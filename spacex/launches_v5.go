@@ -0,0 +1,147 @@
+package spacex
+
+import (
+	"context"
+	"fmt"
+)
+
+// apiVersionV5 is the API version serving the v5 launches endpoints.
+const apiVersionV5 = "v5"
+
+// checkServiceVersion reports an error if the methods of service can't
+// decode the responses of the given API version. The v5 launches don't fit
+// the Launch model and are served by the V5 methods instead.
+func checkServiceVersion(service, version string) error {
+	if service == "launches" && version == apiVersionV5 {
+		return fmt.Errorf("spacex: the launches methods don't support API version %s, use the V5 methods such as GetLaunchV5", version)
+	}
+	return nil
+}
+
+// LaunchV5 represents a SpaceX launch as returned by the v5 launches
+// endpoints. It differs from Launch in listing crew members along with
+// their role on the flight.
+type LaunchV5 struct {
+	FlightNumber       int           `json:"flight_number"`
+	Name               string        `json:"name"`
+	DateUTC            string        `json:"date_utc"`
+	DateUnix           int64         `json:"date_unix"`
+	DateLocal          string        `json:"date_local"`
+	DatePrecision      string        `json:"date_precision"`
+	StaticFireDateUTC  *string       `json:"static_fire_date_utc"`
+	StaticFireDateUnix *int64        `json:"static_fire_date_unix"`
+	TBD                bool          `json:"tbd"`
+	Net                bool          `json:"net"`
+	Window             *int          `json:"window"`
+	Rocket             *string       `json:"rocket"`
+	Success            *bool         `json:"success"`
+	Failures           []*Failure    `json:"failures"`
+	Upcoming           bool          `json:"upcoming"`
+	Details            *string       `json:"details"`
+	Fairings           *Fairings     `json:"fairings"`
+	Crew               []*CrewRole   `json:"crew"`
+	Ships              []string      `json:"ships"`
+	Capsules           []string      `json:"capsules"`
+	Payloads           []string      `json:"payloads"`
+	Launchpad          *string       `json:"launchpad"`
+	Cores              []*CoreLaunch `json:"cores"`
+	Links              *LaunchLinks  `json:"links"`
+	AutoUpdate         bool          `json:"auto_update"`
+	LaunchLibraryID    *string       `json:"launch_library_id"`
+	ID                 string        `json:"id"`
+
+	Document
+}
+
+// MarshalJSON implements json.Marshaler, including the fields in Extra.
+func (l LaunchV5) MarshalJSON() ([]byte, error) {
+	type launchV5 LaunchV5
+	return marshalModel(launchV5(l), l.Extra)
+}
+
+// CrewRole represents a crew member flying on a v5 launch.
+type CrewRole struct {
+	Crew *string `json:"crew"`
+	Role *string `json:"role"`
+}
+
+// LaunchV5QueryResults represents the result of a v5 launch query.
+type LaunchV5QueryResults = Page[*LaunchV5]
+
+// ListAllLaunchesV5 lists all launches using the v5 API.
+func (s *LaunchesService) ListAllLaunchesV5(ctx context.Context, opts ...CallOption) ([]*LaunchV5, *Response, error) {
+	return s.listV5(ctx, "launches", opts...)
+}
+
+// GetLaunchV5 retrieves a specific launch using the v5 API.
+func (s *LaunchesService) GetLaunchV5(ctx context.Context, id string, opts ...CallOption) (*LaunchV5, *Response, error) {
+	return s.getV5(ctx, fmt.Sprintf("launches/%s", id), opts...)
+}
+
+// GetLatestLaunchV5 retrieves the latest launch using the v5 API.
+func (s *LaunchesService) GetLatestLaunchV5(ctx context.Context, opts ...CallOption) (*LaunchV5, *Response, error) {
+	return s.getV5(ctx, "launches/latest", opts...)
+}
+
+// GetNextLaunchV5 retrieves the next launch using the v5 API.
+func (s *LaunchesService) GetNextLaunchV5(ctx context.Context, opts ...CallOption) (*LaunchV5, *Response, error) {
+	return s.getV5(ctx, "launches/next", opts...)
+}
+
+// ListPastLaunchesV5 lists past launches using the v5 API.
+func (s *LaunchesService) ListPastLaunchesV5(ctx context.Context, opts ...CallOption) ([]*LaunchV5, *Response, error) {
+	return s.listV5(ctx, "launches/past", opts...)
+}
+
+// ListUpcomingLaunchesV5 lists upcoming launches using the v5 API.
+func (s *LaunchesService) ListUpcomingLaunchesV5(ctx context.Context, opts ...CallOption) ([]*LaunchV5, *Response, error) {
+	return s.listV5(ctx, "launches/upcoming", opts...)
+}
+
+// QueryLaunchesV5 queries for launches using the v5 API. The query can be
+// built with the query package.
+func (s *LaunchesService) QueryLaunchesV5(ctx context.Context, query map[string]interface{}, opts ...CallOption) (*LaunchV5QueryResults, *Response, error) {
+	u := "launches/query"
+	req, err := s.client.newVersionedRequest(ctx, apiVersionV5, "POST", u, query, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	results := new(LaunchV5QueryResults)
+	resp, err := s.client.do(ctx, req, results)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return results, resp, nil
+}
+
+func (s *LaunchesService) listV5(ctx context.Context, u string, opts ...CallOption) ([]*LaunchV5, *Response, error) {
+	req, err := s.client.newVersionedRequest(ctx, apiVersionV5, "GET", u, nil, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var launches []*LaunchV5
+	resp, err := s.client.do(ctx, req, &launches)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return launches, resp, nil
+}
+
+func (s *LaunchesService) getV5(ctx context.Context, u string, opts ...CallOption) (*LaunchV5, *Response, error) {
+	req, err := s.client.newVersionedRequest(ctx, apiVersionV5, "GET", u, nil, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	launch := new(LaunchV5)
+	resp, err := s.client.do(ctx, req, launch)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return launch, resp, nil
+}
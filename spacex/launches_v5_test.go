@@ -0,0 +1,103 @@
+package spacex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestLaunchesService_GetLatestLaunchV5(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+	client.BaseURL, _ = url.Parse(serverURL + "/v4/")
+
+	mux.HandleFunc("/v5/launches/latest", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Request method = %v, want %v", r.Method, "GET")
+		}
+		fmt.Fprint(w, `{"name":"Crew-5","crew":[{"crew":"62dd7196202306255024d13c","role":"Commander"}]}`)
+	})
+	mux.HandleFunc("/v4/rockets/5e9d0d95eda69955f709d1eb", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"Falcon 9"}`)
+	})
+
+	ctx := context.Background()
+	launch, _, err := client.Launches.GetLatestLaunchV5(ctx)
+	if err != nil {
+		t.Fatalf("Launches.GetLatestLaunchV5 returned error: %v", err)
+	}
+	if len(launch.Crew) != 1 || *launch.Crew[0].Role != "Commander" {
+		t.Errorf("Launches.GetLatestLaunchV5 returned crew %+v, want one commander", launch.Crew)
+	}
+
+	// Other services stay on the base URL version.
+	if _, _, err := client.Rockets.GetRocket(ctx, "5e9d0d95eda69955f709d1eb"); err != nil {
+		t.Fatalf("Rockets.GetRocket returned error: %v", err)
+	}
+}
+
+func TestClient_APIVersions(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+	client.BaseURL, _ = url.Parse(serverURL + "/v4/")
+	client.APIVersions = map[string]string{"capsules": "v5"}
+
+	mux.HandleFunc("/v5/capsules/5e9e2c5bf35918ed873b2664", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"serial":"C101"}`)
+	})
+
+	capsule, _, err := client.Capsules.GetCapsule(context.Background(), "5e9e2c5bf35918ed873b2664")
+	if err != nil {
+		t.Fatalf("Capsules.GetCapsule returned error: %v", err)
+	}
+	if capsule.Serial != "C101" {
+		t.Errorf("Capsules.GetCapsule returned %+v, want %+v", capsule.Serial, "C101")
+	}
+}
+
+func TestClient_Endpoint(t *testing.T) {
+	client := NewClient(nil)
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://api.spacexdata.com/v4/launches/next", "launches/next"},
+		{"https://api.spacexdata.com/v5/launches/query", "launches/query"},
+		{"https://api.spacexdata.com/v4/company", "company"},
+	}
+
+	for _, tt := range tests {
+		req, _ := http.NewRequest("GET", tt.url, nil)
+		if got := client.endpoint(req); got != tt.want {
+			t.Errorf("endpoint(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestLaunchesService_RejectsV5(t *testing.T) {
+	if _, err := NewClientWithOptions(WithServiceAPIVersion("launches", "v5")); err == nil {
+		t.Errorf("WithServiceAPIVersion(launches, v5) returned no error")
+	}
+
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+	client.BaseURL, _ = url.Parse(serverURL + "/v4/")
+	client.APIVersions = map[string]string{"launches": "v5"}
+	mux.HandleFunc("/v5/launches/latest", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("v4 method sent a request to %v", r.URL)
+	})
+
+	if _, _, err := client.Launches.GetLatestLaunch(context.Background()); err == nil {
+		t.Errorf("Launches.GetLatestLaunch returned no error with launches on v5")
+	}
+
+	// The V5 methods are unaffected.
+	mux.HandleFunc("/v5/launches/next", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"Crew-6"}`)
+	})
+	if _, _, err := client.Launches.GetNextLaunchV5(context.Background()); err != nil {
+		t.Errorf("Launches.GetNextLaunchV5 returned error: %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package spacex
 import (
 	"context"
 	"fmt"
+	"iter"
 )
 
 // LaunchpadsService handles communication with the launchpad related
@@ -25,38 +26,67 @@ type Launchpad struct {
 	LaunchSuccesses int      `json:"launch_successes"`
 	Rockets         []string `json:"rockets"`
 	Launches        []string `json:"launches"`
+	ID              string   `json:"id"`
+
+	Document
+}
+
+// MarshalJSON implements json.Marshaler, including the fields in Extra.
+func (l Launchpad) MarshalJSON() ([]byte, error) {
+	type launchpad Launchpad
+	return marshalModel(launchpad(l), l.Extra)
 }
 
+// LaunchpadQueryResults represents the result of a launchpad query.
+type LaunchpadQueryResults = Page[*Launchpad]
+
 // ListAllLaunchpads lists all launchpads.
-func (s *LaunchpadsService) ListAllLaunchpads(ctx context.Context) ([]*Launchpad, error) {
+func (s *LaunchpadsService) ListAllLaunchpads(ctx context.Context, opts ...CallOption) ([]*Launchpad, *Response, error) {
 	u := "launchpads"
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var launchpads []*Launchpad
-	_, err = s.client.do(ctx, req, &launchpads)
+	resp, err := s.client.do(ctx, req, &launchpads)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return launchpads, nil
+	return launchpads, resp, nil
+}
+
+// All iterates over all launchpads, decoding them one at a time.
+func (s *LaunchpadsService) All(ctx context.Context, opts ...CallOption) iter.Seq2[*Launchpad, error] {
+	return streamList[Launchpad](ctx, s.client, "launchpads", opts...)
 }
 
 // GetLaunchpad retrieves a specific launchpad.
-func (s *LaunchpadsService) GetLaunchpad(ctx context.Context, id string) (*Launchpad, error) {
+func (s *LaunchpadsService) GetLaunchpad(ctx context.Context, id string, opts ...CallOption) (*Launchpad, *Response, error) {
 	u := fmt.Sprintf("launchpads/%s", id)
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	launchpad := new(Launchpad)
-	_, err = s.client.do(ctx, req, launchpad)
+	resp, err := s.client.do(ctx, req, launchpad)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return launchpad, nil
+	return launchpad, resp, nil
+}
+
+// GetMany retrieves the launchpads with the given IDs, in order. IDs that
+// don't exist have nil entries and are reported in a *MissingIDsError.
+func (s *LaunchpadsService) GetMany(ctx context.Context, ids []string, opts ...CallOption) ([]*Launchpad, *Response, error) {
+	return getMany(ctx, s.client, "launchpads", ids, func(l *Launchpad) string { return l.ID }, opts)
+}
+
+// QueryLaunchpads queries for launchpads. The query can be built with the
+// query package.
+func (s *LaunchpadsService) QueryLaunchpads(ctx context.Context, query map[string]interface{}, opts ...CallOption) (*LaunchpadQueryResults, *Response, error) {
+	return queryPage[*Launchpad](ctx, s.client, "launchpads", query, opts)
 }
@@ -0,0 +1,221 @@
+package spacex
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the latency
+// histogram buckets used by NewMetrics when none are given.
+var DefaultLatencyBuckets = []float64{0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Metrics collects per-endpoint counters and latency histograms of the API
+// calls made by a Client. Endpoints are keyed by pattern, such as
+// "launches/query" or "starlink/{id}". Metrics implements http.Handler,
+// serving the metrics in the Prometheus text exposition format. It is safe
+// for concurrent use and may be shared between clients.
+type Metrics struct {
+	buckets []float64
+
+	mu        sync.Mutex
+	endpoints map[string]*endpointMetrics
+}
+
+// endpointMetrics are the metrics of a single endpoint pattern.
+type endpointMetrics struct {
+	requests map[string]uint64 // by status code
+	errors   map[string]uint64 // by status code
+	cache    map[string]uint64 // by cache result
+	retries  uint64
+
+	bucketCounts []uint64 // non-cumulative, one per bucket
+	count        uint64
+	sum          float64
+}
+
+// Status code label of calls that received no response.
+const noResponseCode = "none"
+
+// Cache result labels.
+const (
+	cacheClient      = "client"      // served from the client's Cache
+	cacheRevalidated = "revalidated" // 304 Not Modified
+	cacheAPI         = "api"         // served from the API's cache
+	cacheMiss        = "miss"
+)
+
+// NewMetrics returns an empty Metrics with latency histogram buckets at the
+// given upper bounds in seconds, or DefaultLatencyBuckets if none are given.
+func NewMetrics(buckets ...float64) (*Metrics, error) {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = slices.Clone(buckets)
+	for i, b := range buckets {
+		if b <= 0 {
+			return nil, fmt.Errorf("latency buckets must be positive, got %v", b)
+		}
+		if i > 0 && b <= buckets[i-1] {
+			return nil, errors.New("latency buckets must be in increasing order")
+		}
+	}
+	return &Metrics{
+		buckets:   buckets,
+		endpoints: make(map[string]*endpointMetrics),
+	}, nil
+}
+
+// observe records a call to the endpoint pattern.
+func (m *Metrics) observe(pattern string, latency time.Duration, retries int, resp *Response, err error) {
+	code, cache := noResponseCode, ""
+	if resp != nil && resp.Response != nil {
+		code = strconv.Itoa(resp.StatusCode)
+		switch {
+		case resp.FromCache:
+			cache = cacheClient
+		case resp.NotModified:
+			cache = cacheRevalidated
+		case resp.CacheHit:
+			cache = cacheAPI
+		default:
+			cache = cacheMiss
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.endpoints[pattern]
+	if !ok {
+		e = &endpointMetrics{
+			requests:     make(map[string]uint64),
+			errors:       make(map[string]uint64),
+			cache:        make(map[string]uint64),
+			bucketCounts: make([]uint64, len(m.buckets)),
+		}
+		m.endpoints[pattern] = e
+	}
+
+	e.requests[code]++
+	if err != nil {
+		e.errors[code]++
+	}
+	if cache != "" {
+		e.cache[cache]++
+	}
+	e.retries += uint64(retries)
+
+	seconds := latency.Seconds()
+	if i, _ := slices.BinarySearch(m.buckets, seconds); i < len(m.buckets) {
+		e.bucketCounts[i]++
+	}
+	e.count++
+	e.sum += seconds
+}
+
+// Reset clears all collected metrics.
+func (m *Metrics) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.endpoints = make(map[string]*endpointMetrics)
+}
+
+// ServeHTTP serves the metrics in the Prometheus text exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+// WriteTo writes the metrics to w in the Prometheus text exposition format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+
+	m.mu.Lock()
+	patterns := make([]string, 0, len(m.endpoints))
+	for p := range m.endpoints {
+		patterns = append(patterns, p)
+	}
+	slices.Sort(patterns)
+
+	writeHeader(bw, "spacex_requests_total", "counter", "API calls by endpoint and HTTP status code.")
+	for _, p := range patterns {
+		writeCounters(bw, "spacex_requests_total", p, "code", m.endpoints[p].requests)
+	}
+	writeHeader(bw, "spacex_request_errors_total", "counter", "API calls that returned an error, by endpoint and HTTP status code.")
+	for _, p := range patterns {
+		writeCounters(bw, "spacex_request_errors_total", p, "code", m.endpoints[p].errors)
+	}
+	writeHeader(bw, "spacex_cache_results_total", "counter", "API responses by endpoint and cache result: client, revalidated, api or miss.")
+	for _, p := range patterns {
+		writeCounters(bw, "spacex_cache_results_total", p, "result", m.endpoints[p].cache)
+	}
+	writeHeader(bw, "spacex_retries_total", "counter", "Retried attempts by endpoint.")
+	for _, p := range patterns {
+		fmt.Fprintf(bw, "spacex_retries_total{endpoint=%s} %d\n", quoteLabel(p), m.endpoints[p].retries)
+	}
+	writeHeader(bw, "spacex_request_duration_seconds", "histogram", "API call latency by endpoint.")
+	for _, p := range patterns {
+		e := m.endpoints[p]
+		endpoint := quoteLabel(p)
+		var cumulative uint64
+		for i, b := range m.buckets {
+			cumulative += e.bucketCounts[i]
+			fmt.Fprintf(bw, "spacex_request_duration_seconds_bucket{endpoint=%s,le=%q} %d\n",
+				endpoint, strconv.FormatFloat(b, 'g', -1, 64), cumulative)
+		}
+		fmt.Fprintf(bw, "spacex_request_duration_seconds_bucket{endpoint=%s,le=\"+Inf\"} %d\n", endpoint, e.count)
+		fmt.Fprintf(bw, "spacex_request_duration_seconds_sum{endpoint=%s} %s\n",
+			endpoint, strconv.FormatFloat(e.sum, 'g', -1, 64))
+		fmt.Fprintf(bw, "spacex_request_duration_seconds_count{endpoint=%s} %d\n", endpoint, e.count)
+	}
+	m.mu.Unlock()
+
+	err := bw.Flush()
+	return cw.n, err
+}
+
+func writeHeader(w io.Writer, name, typ, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+// writeCounters writes one sample of the counter name per value of label,
+// in order.
+func writeCounters(w io.Writer, name, pattern, label string, counts map[string]uint64) {
+	values := make([]string, 0, len(counts))
+	for v := range counts {
+		values = append(values, v)
+	}
+	slices.Sort(values)
+	for _, v := range values {
+		fmt.Fprintf(w, "%s{endpoint=%s,%s=%s} %d\n", name, quoteLabel(pattern), label, quoteLabel(v), counts[v])
+	}
+}
+
+// labelEscaper escapes label values as required by the text exposition
+// format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func quoteLabel(v string) string {
+	return `"` + labelEscaper.Replace(v) + `"`
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,107 @@
+package spacex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetrics(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	metrics, err := NewMetrics()
+	if err != nil {
+		t.Fatalf("NewMetrics returned error: %v", err)
+	}
+	client.Metrics = metrics
+	client.Cache = NewMemoryCache(10)
+	client.RetryPolicy = testRetryPolicy()
+
+	attempts := 0
+	mux.HandleFunc("/starlink/5eed770f096e59000698560d", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"version":"v1.0"}`)
+	})
+	mux.HandleFunc("/starlink/unknown", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Starlink.GetStarlink(ctx, "5eed770f096e59000698560d"); err != nil {
+			t.Fatalf("Starlink.GetStarlink returned error: %v", err)
+		}
+	}
+	if _, _, err := client.Starlink.GetStarlink(ctx, "unknown"); err == nil {
+		t.Fatal("Starlink.GetStarlink returned no error")
+	}
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if got, want := rec.Header().Get("Content-Type"), "text/plain; version=0.0.4; charset=utf-8"; got != want {
+		t.Errorf("Content-Type = %q, want %q", got, want)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		"# TYPE spacex_requests_total counter\n",
+		`spacex_requests_total{endpoint="starlink/{id}",code="200"} 2` + "\n",
+		`spacex_requests_total{endpoint="starlink/{id}",code="404"} 1` + "\n",
+		`spacex_request_errors_total{endpoint="starlink/{id}",code="404"} 1` + "\n",
+		`spacex_cache_results_total{endpoint="starlink/{id}",result="client"} 1` + "\n",
+		`spacex_cache_results_total{endpoint="starlink/{id}",result="miss"} 2` + "\n",
+		`spacex_retries_total{endpoint="starlink/{id}"} 1` + "\n",
+		"# TYPE spacex_request_duration_seconds histogram\n",
+		`spacex_request_duration_seconds_bucket{endpoint="starlink/{id}",le="+Inf"} 3` + "\n",
+		`spacex_request_duration_seconds_count{endpoint="starlink/{id}"} 3` + "\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics missing %q, got:\n%s", want, body)
+		}
+	}
+}
+
+func TestMetrics_Histogram(t *testing.T) {
+	m, err := NewMetrics(0.1, 1)
+	if err != nil {
+		t.Fatalf("NewMetrics returned error: %v", err)
+	}
+	m.observe("company", 50*time.Millisecond, 0, nil, context.DeadlineExceeded)
+	m.observe("company", 100*time.Millisecond, 0, nil, nil)
+	m.observe("company", 2*time.Second, 0, nil, nil)
+
+	var b strings.Builder
+	n, err := m.WriteTo(&b)
+	if err != nil {
+		t.Fatalf("WriteTo returned error: %v", err)
+	}
+	if n != int64(b.Len()) {
+		t.Errorf("WriteTo returned %d, wrote %d bytes", n, b.Len())
+	}
+	for _, want := range []string{
+		`spacex_request_errors_total{endpoint="company",code="none"} 1` + "\n",
+		`spacex_request_duration_seconds_bucket{endpoint="company",le="0.1"} 2` + "\n",
+		`spacex_request_duration_seconds_bucket{endpoint="company",le="1"} 2` + "\n",
+		`spacex_request_duration_seconds_bucket{endpoint="company",le="+Inf"} 3` + "\n",
+		`spacex_request_duration_seconds_sum{endpoint="company"} 2.15` + "\n",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("metrics missing %q, got:\n%s", want, b.String())
+		}
+	}
+}
+
+func TestNewMetrics_InvalidBuckets(t *testing.T) {
+	for _, buckets := range [][]float64{{0}, {1, 0.5}, {1, 1}} {
+		if _, err := NewMetrics(buckets...); err == nil {
+			t.Errorf("NewMetrics(%v) returned no error", buckets)
+		}
+	}
+}
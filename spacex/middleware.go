@@ -0,0 +1,131 @@
+package spacex
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// maxLoggedBody is the number of body bytes logged by LoggingMiddleware.
+const maxLoggedBody = 4096
+
+// Doer sends an HTTP request and returns its response. *http.Client
+// implements Doer.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc is an adapter allowing an ordinary function to be used as a Doer.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps a Doer to intercept requests and responses.
+type Middleware func(next Doer) Doer
+
+// Use appends middleware to the chain wrapping every HTTP request sent by
+// the client, including each retry attempt. The first middleware added is
+// the outermost. Use must not be called concurrently with requests.
+func (c *Client) Use(mw ...Middleware) {
+	c.middleware = append(c.middleware, mw...)
+}
+
+// doer returns the client's HTTP client wrapped in its middleware chain.
+func (c *Client) doer() Doer {
+	var d Doer = c.client
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		d = c.middleware[i](d)
+	}
+	return d
+}
+
+// HeaderMiddleware returns a Middleware setting the given headers on every
+// request, replacing any existing values.
+func HeaderMiddleware(h http.Header) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			for k, v := range h {
+				req.Header[http.CanonicalHeaderKey(k)] = append([]string(nil), v...)
+			}
+			return next.Do(req)
+		})
+	}
+}
+
+// TimingMiddleware returns a Middleware calling fn with the duration of
+// every HTTP round trip. resp is nil if the request failed.
+func TimingMiddleware(fn func(req *http.Request, resp *http.Response, d time.Duration)) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req)
+			fn(req, resp, time.Since(start))
+			return resp, err
+		})
+	}
+}
+
+// LoggingMiddleware returns a Middleware logging every request to logger.
+// Successful requests are logged at debug level and failures at warn level.
+// If logBodies is set, the first bytes of request and response bodies are
+// included.
+func LoggingMiddleware(logger *slog.Logger, logBodies bool) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			attrs := []any{
+				slog.String("method", req.Method),
+				slog.String("url", req.URL.String()),
+			}
+			if logBodies && req.GetBody != nil {
+				if body, err := req.GetBody(); err == nil {
+					attrs = append(attrs, slog.String("request_body", readLogged(body)))
+					body.Close()
+				}
+			}
+
+			start := time.Now()
+			resp, err := next.Do(req)
+			attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+			if err != nil {
+				logger.Warn("spacex: request failed", append(attrs, slog.Any("error", err))...)
+				return resp, err
+			}
+
+			attrs = append(attrs, slog.Int("status", resp.StatusCode))
+			if logBodies {
+				data, readErr := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				resp.Body = io.NopCloser(bytes.NewReader(data))
+				if readErr != nil {
+					return resp, readErr
+				}
+				attrs = append(attrs, slog.String("response_body", truncate(data)))
+			}
+
+			level := slog.LevelDebug
+			if resp.StatusCode >= 400 {
+				level = slog.LevelWarn
+			}
+			logger.Log(req.Context(), level, "spacex: request", attrs...)
+			return resp, nil
+		})
+	}
+}
+
+func readLogged(r io.Reader) string {
+	data, _ := io.ReadAll(io.LimitReader(r, maxLoggedBody+1))
+	return truncate(data)
+}
+
+func truncate(data []byte) string {
+	if len(data) > maxLoggedBody {
+		return string(data[:maxLoggedBody]) + "..."
+	}
+	return string(data)
+}
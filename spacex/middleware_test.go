@@ -0,0 +1,100 @@
+package spacex
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_Use(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/payloads/5eb0e4c6b6c3bb0006eeb21e", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Test"); got != "outer" {
+			t.Errorf("X-Test header = %q, want %q", got, "outer")
+		}
+		fmt.Fprint(w, `{"name":"Iridium NEXT"}`)
+	})
+
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.Do(req)
+			})
+		}
+	}
+
+	var timed time.Duration
+	client.Use(
+		trace("first"),
+		HeaderMiddleware(http.Header{"X-Test": {"outer"}}),
+		trace("second"),
+		TimingMiddleware(func(req *http.Request, resp *http.Response, d time.Duration) {
+			timed = d
+		}),
+	)
+
+	if _, _, err := client.Payloads.GetPayload(context.Background(), "5eb0e4c6b6c3bb0006eeb21e"); err != nil {
+		t.Fatalf("Payloads.GetPayload returned error: %v", err)
+	}
+	if got := strings.Join(order, ","); got != "first,second" {
+		t.Errorf("middleware order = %q, want %q", got, "first,second")
+	}
+	if timed <= 0 {
+		t.Errorf("TimingMiddleware reported %v, want positive duration", timed)
+	}
+}
+
+func TestClient_UseFaultInjection(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	errInjected := errors.New("injected")
+	client.Use(func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, errInjected
+		})
+	})
+
+	if _, _, err := client.Ships.ListAllShips(context.Background()); !errors.Is(err, errInjected) {
+		t.Errorf("Ships.ListAllShips returned %v, want %v", err, errInjected)
+	}
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/dragons/query", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"docs":[]}`)
+	})
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client.Use(LoggingMiddleware(logger, true))
+
+	q := map[string]interface{}{"query": map[string]interface{}{}}
+	results, _, err := client.Dragons.QueryDragons(context.Background(), q)
+	if err != nil {
+		t.Fatalf("Dragons.QueryDragons returned error: %v", err)
+	}
+	if results.Docs == nil {
+		t.Errorf("response body was not passed on after logging")
+	}
+
+	out := buf.String()
+	for _, want := range []string{"method=POST", "status=200", `request_body="{\"query\":{}}\n"`, `response_body="{\"docs\":[]}"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log output %q does not contain %q", out, want)
+		}
+	}
+}
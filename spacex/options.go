@@ -0,0 +1,290 @@
+package spacex
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Option configures a Client created by NewClientWithOptions.
+type Option func(*Client) error
+
+var apiVersionRE = regexp.MustCompile(`^v[0-9]+$`)
+
+// NewClientWithOptions returns a new SpaceX API client configured by opts.
+// Options are applied in order and validated eagerly.
+func NewClientWithOptions(opts ...Option) (*Client, error) {
+	c := newClient(http.DefaultClient)
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// WithHTTPClient sets the HTTP client used to send requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return errors.New("http client must not be nil")
+		}
+		c.client = httpClient
+		return nil
+	}
+}
+
+// WithBaseURL sets the base URL of the API, including the version path
+// segment. The URL must have a trailing slash.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) error {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return fmt.Errorf("invalid base URL: %w", err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("base URL %q must be absolute", baseURL)
+		}
+		if !strings.HasSuffix(u.Path, "/") {
+			return fmt.Errorf("baseURL must have a trailing slash, but %q does not", baseURL)
+		}
+		c.BaseURL = u
+		return nil
+	}
+}
+
+// WithAPIVersion sets the API version, such as "v4", by replacing the
+// version path segment of the base URL, or appending one if it has none.
+func WithAPIVersion(version string) Option {
+	return func(c *Client) error {
+		if !apiVersionRE.MatchString(version) {
+			return fmt.Errorf("invalid API version %q", version)
+		}
+		u := *c.BaseURL
+		u.Path = withVersion(u.Path, version)
+		c.BaseURL = &u
+		return nil
+	}
+}
+
+// WithServiceAPIVersion sets the API version, such as "v5", used by a single
+// service identified by its path, such as "capsules". Other services keep
+// using the version of the base URL. The launches service can't be moved to
+// v5, whose launches are retrieved with the V5 methods of LaunchesService.
+func WithServiceAPIVersion(service, version string) Option {
+	return func(c *Client) error {
+		if service == "" || strings.Contains(service, "/") {
+			return fmt.Errorf("invalid service %q", service)
+		}
+		if !apiVersionRE.MatchString(version) {
+			return fmt.Errorf("invalid API version %q", version)
+		}
+		if err := checkServiceVersion(service, version); err != nil {
+			return err
+		}
+		if c.APIVersions == nil {
+			c.APIVersions = make(map[string]string)
+		}
+		c.APIVersions[service] = version
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) error {
+		if ua == "" {
+			return errors.New("user agent must not be empty")
+		}
+		c.UserAgent = ua
+		return nil
+	}
+}
+
+// WithTimeout sets the overall timeout of each HTTP request. The configured
+// HTTP client is copied rather than modified.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) error {
+		if d <= 0 {
+			return fmt.Errorf("timeout must be positive, got %v", d)
+		}
+		hc := *c.client
+		hc.Timeout = d
+		c.client = &hc
+		return nil
+	}
+}
+
+// WithRetry sets the policy used to retry failed requests.
+func WithRetry(p *RetryPolicy) Option {
+	return func(c *Client) error {
+		if p != nil {
+			if p.BaseDelay < 0 || p.MaxDelay < 0 {
+				return errors.New("retry delays must not be negative")
+			}
+			if p.Jitter < 0 || p.Jitter > 1 {
+				return fmt.Errorf("retry jitter must be between 0 and 1, got %v", p.Jitter)
+			}
+		}
+		c.RetryPolicy = p
+		return nil
+	}
+}
+
+// WithRateLimit throttles the client to requestsPerSecond requests on
+// average, with bursts of up to burst requests.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *Client) error {
+		if !(requestsPerSecond > 0) {
+			return fmt.Errorf("rate limit must be positive, got %v", requestsPerSecond)
+		}
+		c.RateLimiter = NewRateLimiter(requestsPerSecond, burst)
+		return nil
+	}
+}
+
+// WithGetManyBatchSize sets the maximum number of IDs the GetMany methods
+// look up per query request. The default is 100.
+func WithGetManyBatchSize(n int) Option {
+	return func(c *Client) error {
+		if n <= 0 {
+			return fmt.Errorf("GetMany batch size must be positive, got %d", n)
+		}
+		c.getManyBatchSize = n
+		return nil
+	}
+}
+
+// withVersion returns the base path p with its version segment set to version.
+func withVersion(p, version string) string {
+	return withoutVersion(p) + version + "/"
+}
+
+// withoutVersion returns the base path p with its version segment removed.
+// The result always ends in a slash.
+func withoutVersion(p string) string {
+	p = strings.TrimSuffix(p, "/")
+	if apiVersionRE.MatchString(path.Base(p)) {
+		p = path.Dir(p)
+	}
+	return strings.TrimSuffix(p, "/") + "/"
+}
+
+// WithCache enables response caching in cache. A nil policy uses
+// DefaultCachePolicy.
+func WithCache(cache Cache, policy *CachePolicy) Option {
+	return func(c *Client) error {
+		if cache == nil {
+			return errors.New("cache must not be nil")
+		}
+		if policy != nil {
+			if policy.DefaultTTL < 0 {
+				return errors.New("cache TTLs must not be negative")
+			}
+			for endpoint, ttl := range policy.TTLs {
+				if ttl < 0 {
+					return fmt.Errorf("cache TTL for %q must not be negative", endpoint)
+				}
+			}
+		}
+		c.Cache = cache
+		c.CachePolicy = policy
+		return nil
+	}
+}
+
+// WithConditionalRequests enables revalidation of GET requests with ETag and
+// Last-Modified validators. See Client.EnableConditionalRequests.
+func WithConditionalRequests() Option {
+	return func(c *Client) error {
+		c.EnableConditionalRequests()
+		return nil
+	}
+}
+
+// WithMiddleware adds middleware wrapping every HTTP request. See Client.Use.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Client) error {
+		for _, m := range mw {
+			if m == nil {
+				return errors.New("middleware must not be nil")
+			}
+		}
+		c.Use(mw...)
+		return nil
+	}
+}
+
+// WithStrictDecoding enables schema drift reporting. See
+// Client.EnableStrictDecoding.
+func WithStrictDecoding() Option {
+	return func(c *Client) error {
+		c.EnableStrictDecoding()
+		return nil
+	}
+}
+
+// WithRawDocuments keeps the JSON document of every decoded model. See
+// Client.EnableRawDocuments.
+func WithRawDocuments() Option {
+	return func(c *Client) error {
+		c.EnableRawDocuments()
+		return nil
+	}
+}
+
+// WithRequestCoalescing makes concurrent identical requests share a single
+// round trip. See Client.EnableRequestCoalescing.
+func WithRequestCoalescing() Option {
+	return func(c *Client) error {
+		c.EnableRequestCoalescing()
+		return nil
+	}
+}
+
+// WithCircuitBreaker enables a circuit breaker configured by settings. See
+// NewCircuitBreaker for the defaults.
+func WithCircuitBreaker(settings CircuitBreakerSettings) Option {
+	return func(c *Client) error {
+		if settings.FailureThreshold < 0 || settings.CoolDown < 0 {
+			return errors.New("circuit breaker settings must not be negative")
+		}
+		c.CircuitBreaker = NewCircuitBreaker(settings)
+		return nil
+	}
+}
+
+// WithFailover sets an ordered list of base URLs to fail over between. See
+// Client.SetFailover.
+func WithFailover(probeInterval time.Duration, baseURLs ...string) Option {
+	return func(c *Client) error {
+		return c.SetFailover(probeInterval, baseURLs...)
+	}
+}
+
+// WithTracer traces every API call with t.
+func WithTracer(t Tracer) Option {
+	return func(c *Client) error {
+		if t == nil {
+			return errors.New("tracer must not be nil")
+		}
+		c.Tracer = t
+		return nil
+	}
+}
+
+// WithMetrics records metrics of every API call in m.
+func WithMetrics(m *Metrics) Option {
+	return func(c *Client) error {
+		if m == nil {
+			return errors.New("metrics must not be nil")
+		}
+		c.Metrics = m
+		return nil
+	}
+}
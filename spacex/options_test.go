@@ -0,0 +1,71 @@
+package spacex
+
+import (
+	"math"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewClientWithOptions(t *testing.T) {
+	hc := &http.Client{}
+	c, err := NewClientWithOptions(
+		WithHTTPClient(hc),
+		WithBaseURL("https://example.com/api/v4/"),
+		WithAPIVersion("v5"),
+		WithUserAgent("test-agent"),
+		WithTimeout(5*time.Second),
+		WithRetry(DefaultRetryPolicy()),
+		WithGetManyBatchSize(50),
+	)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions returned error: %v", err)
+	}
+
+	if got, want := c.BaseURL.String(), "https://example.com/api/v5/"; got != want {
+		t.Errorf("BaseURL = %q, want %q", got, want)
+	}
+	if c.UserAgent != "test-agent" {
+		t.Errorf("UserAgent = %q, want %q", c.UserAgent, "test-agent")
+	}
+	if c.client.Timeout != 5*time.Second {
+		t.Errorf("Timeout = %v, want %v", c.client.Timeout, 5*time.Second)
+	}
+	if hc.Timeout != 0 {
+		t.Errorf("WithTimeout modified the caller's http.Client")
+	}
+	if c.RetryPolicy == nil {
+		t.Errorf("RetryPolicy is nil, want default policy")
+	}
+	if c.getManyBatchSize != 50 {
+		t.Errorf("getManyBatchSize = %d, want 50", c.getManyBatchSize)
+	}
+	if c.Launches == nil || c.Launches.client != c {
+		t.Errorf("services are not wired to the client")
+	}
+}
+
+func TestNewClientWithOptions_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  Option
+	}{
+		{"no trailing slash", WithBaseURL("https://example.com/v4")},
+		{"relative base URL", WithBaseURL("/v4/")},
+		{"bad version", WithAPIVersion("latest")},
+		{"empty user agent", WithUserAgent("")},
+		{"zero timeout", WithTimeout(0)},
+		{"nil http client", WithHTTPClient(nil)},
+		{"bad jitter", WithRetry(&RetryPolicy{Jitter: 2})},
+		{"zero rate", WithRateLimit(0, 1)},
+		{"NaN rate", WithRateLimit(math.NaN(), 1)},
+		{"zero batch size", WithGetManyBatchSize(0)},
+		{"negative batch size", WithGetManyBatchSize(-1)},
+	}
+
+	for _, tt := range tests {
+		if _, err := NewClientWithOptions(tt.opt); err == nil {
+			t.Errorf("%s: NewClientWithOptions returned no error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Package oteltrace adapts OpenTelemetry tracing to the spacex.Tracer
+// interface. It lives in its own module so that the spacex package doesn't
+// depend on OpenTelemetry.
+//
+// Usage:
+//
+//	client, err := spacex.NewClientWithOptions(
+//		spacex.WithTracer(oteltrace.NewTracer(nil)),
+//	)
+package oteltrace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/catdevman/go-spacex/spacex"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the spans of the SpaceX API client.
+const instrumentationName = "github.com/catdevman/go-spacex/spacex"
+
+// Tracer starts an OpenTelemetry client span for every API call.
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+// NewTracer returns a Tracer creating spans with tp. If tp is nil, the
+// global TracerProvider is used.
+func NewTracer(tp trace.TracerProvider) *Tracer {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return &Tracer{tracer: tp.Tracer(instrumentationName)}
+}
+
+// Start implements spacex.Tracer.
+func (t *Tracer) Start(ctx context.Context, name string) (context.Context, spacex.Span) {
+	ctx, span := t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+	return ctx, &Span{span: span}
+}
+
+// Span is an OpenTelemetry span of an API call.
+type Span struct {
+	span trace.Span
+}
+
+// SetAttributes implements spacex.Span.
+func (s *Span) SetAttributes(attrs ...spacex.Attribute) {
+	kvs := make([]attribute.KeyValue, len(attrs))
+	for i, a := range attrs {
+		kvs[i] = keyValue(a)
+	}
+	s.span.SetAttributes(kvs...)
+}
+
+// RecordError implements spacex.Span. The span status is set to Error.
+func (s *Span) RecordError(err error) {
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+// End implements spacex.Span.
+func (s *Span) End() {
+	s.span.End()
+}
+
+func keyValue(a spacex.Attribute) attribute.KeyValue {
+	key := attribute.Key(a.Key)
+	switch v := a.Value.(type) {
+	case string:
+		return key.String(v)
+	case int:
+		return key.Int(v)
+	case int64:
+		return key.Int64(v)
+	case bool:
+		return key.Bool(v)
+	}
+	return key.String(fmt.Sprint(a.Value))
+}
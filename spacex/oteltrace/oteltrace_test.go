@@ -0,0 +1,76 @@
+package oteltrace
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/catdevman/go-spacex/spacex"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracer(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	var parent trace.SpanContext
+	mux.HandleFunc("/company", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"SpaceX"}`)
+	})
+	mux.HandleFunc("/rockets/unknown", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+	})
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	client := spacex.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	client.Tracer = NewTracer(tp)
+	client.Use(func(next spacex.Doer) spacex.Doer {
+		return spacex.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			parent = trace.SpanContextFromContext(req.Context())
+			return next.Do(req)
+		})
+	})
+
+	ctx := context.Background()
+	if _, _, err := client.Company.GetCompanyInfo(ctx); err != nil {
+		t.Fatalf("Company.GetCompanyInfo returned error: %v", err)
+	}
+	if _, _, err := client.Rockets.GetRocket(ctx, "unknown"); err == nil {
+		t.Fatal("Rockets.GetRocket returned no error")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("recorded %d spans, want 2", len(spans))
+	}
+
+	company := spans[0]
+	if company.Name() != "spacex company" || company.SpanKind() != trace.SpanKindClient {
+		t.Errorf("span = %q (%v), want %q (client)", company.Name(), company.SpanKind(), "spacex company")
+	}
+	attrs := attribute.NewSet(company.Attributes()...)
+	if v, _ := attrs.Value(spacex.AttrStatusCode); v.AsInt64() != http.StatusOK {
+		t.Errorf("status code attribute = %v, want %v", v.Emit(), http.StatusOK)
+	}
+	if v, _ := attrs.Value(spacex.AttrService); v.AsString() != "company" {
+		t.Errorf("service attribute = %q, want %q", v.AsString(), "company")
+	}
+
+	rocket := spans[1]
+	if rocket.Status().Code != codes.Error || len(rocket.Events()) == 0 {
+		t.Errorf("span status = %v with %d events, want error with recorded exception", rocket.Status(), len(rocket.Events()))
+	}
+	if parent.SpanID() != rocket.SpanContext().SpanID() {
+		t.Errorf("request context carries span %v, want %v", parent.SpanID(), rocket.SpanContext().SpanID())
+	}
+}
@@ -0,0 +1,184 @@
+package spacex
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"maps"
+)
+
+// Page is a page of results of a query endpoint. T is the type of the
+// documents, such as *Launch.
+type Page[T any] struct {
+	Docs          []T  `json:"docs"`
+	TotalDocs     int  `json:"totalDocs"`
+	Limit         int  `json:"limit"`
+	TotalPages    int  `json:"totalPages"`
+	Page          int  `json:"page"`
+	PagingCounter int  `json:"pagingCounter"`
+	HasPrevPage   bool `json:"hasPrevPage"`
+	HasNextPage   bool `json:"hasNextPage"`
+	PrevPage      *int `json:"prevPage"`
+	NextPage      *int `json:"nextPage"`
+}
+
+// QueryFunc is a query method returning pages of T, such as
+// LaunchesService.QueryLaunches.
+type QueryFunc[T any] func(ctx context.Context, query map[string]interface{}, opts ...CallOption) (*Page[T], *Response, error)
+
+// queryPage queries resource, such as "launches", decoding the matching
+// documents into a page of T.
+func queryPage[T any](ctx context.Context, c *Client, resource string, query map[string]interface{}, opts []CallOption) (*Page[T], *Response, error) {
+	u := resource + "/query"
+	req, err := c.newRequest(ctx, "POST", u, query, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	results := new(Page[T])
+	resp, err := c.do(ctx, req, results)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return results, resp, nil
+}
+
+// PaginateOptions configures Paginate.
+type PaginateOptions struct {
+	// Prefetch fetches the next page while the documents of the current
+	// one are consumed.
+	Prefetch bool
+
+	// MaxDocs stops the iteration after this many documents, if positive.
+	MaxDocs int
+
+	// CallOptions apply to every page request.
+	CallOptions []CallOption
+}
+
+// Paginate returns an iterator over the documents matching query across all
+// pages, fetching pages from fetch as they are needed. Pages are requested by
+// page number, or by offset if query sets one. A nil opts fetches pages one at
+// a time without limit. Iteration stops after the first error.
+//
+//	q := query.New(query.Eq("upcoming", false), &query.Options{Limit: 50})
+//	for launch, err := range spacex.Paginate(ctx, client.Launches.QueryLaunches, q, nil) {
+//		...
+//	}
+func Paginate[T any](ctx context.Context, fetch QueryFunc[T], query map[string]interface{}, opts *PaginateOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var o PaginateOptions
+		if opts != nil {
+			o = *opts
+		}
+		var zero T
+
+		q, err := copyQuery(query)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		// Stop an outstanding prefetch when the iteration ends early.
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		type result struct {
+			page *Page[T]
+			err  error
+		}
+		start := func(q map[string]interface{}) <-chan result {
+			ch := make(chan result, 1)
+			if o.Prefetch {
+				go func() {
+					page, _, err := fetch(ctx, q, o.CallOptions...)
+					ch <- result{page, err}
+				}()
+			} else {
+				page, _, err := fetch(ctx, q, o.CallOptions...)
+				ch <- result{page, err}
+			}
+			return ch
+		}
+
+		yielded := 0
+		pending := start(q)
+		for {
+			r := <-pending
+			if r.err != nil {
+				yield(zero, r.err)
+				return
+			}
+			page := r.page
+
+			more := page.HasNextPage && len(page.Docs) > 0 &&
+				(o.MaxDocs <= 0 || yielded+len(page.Docs) < o.MaxDocs)
+			pending = nil
+			if more {
+				q = nextPageQuery(q, page.Page, page.NextPage, len(page.Docs))
+				if o.Prefetch {
+					pending = start(q)
+				}
+			}
+
+			for _, doc := range page.Docs {
+				if o.MaxDocs > 0 && yielded >= o.MaxDocs {
+					return
+				}
+				if !yield(doc, nil) {
+					return
+				}
+				yielded++
+			}
+			if !more {
+				return
+			}
+			if pending == nil {
+				pending = start(q)
+			}
+		}
+	}
+}
+
+// copyQuery returns a deep copy of query as generic JSON values, so that its
+// options can be changed.
+func copyQuery(query map[string]interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+	var q map[string]interface{}
+	if err := json.Unmarshal(data, &q); err != nil {
+		return nil, err
+	}
+	if q == nil {
+		q = make(map[string]interface{})
+	}
+	return q, nil
+}
+
+// nextPageQuery returns a copy of q requesting the page after the one
+// numbered page, which held n documents.
+func nextPageQuery(q map[string]interface{}, page int, nextPage *int, n int) map[string]interface{} {
+	next := maps.Clone(q)
+	options, _ := q["options"].(map[string]interface{})
+	options = maps.Clone(options)
+	if options == nil {
+		options = make(map[string]interface{})
+	}
+	next["options"] = options
+
+	if offset, ok := options["offset"].(float64); ok {
+		// Keep the offset a float64, like the decoded query, so that the
+		// offset of the next page is found again.
+		options["offset"] = offset + float64(n)
+		return next
+	}
+	if nextPage != nil {
+		options["page"] = *nextPage
+	} else {
+		options["page"] = page + 1
+	}
+	return next
+}
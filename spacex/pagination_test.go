@@ -0,0 +1,189 @@
+package spacex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/catdevman/go-spacex/spacex/query"
+)
+
+// handlePages serves total launches named "L1", "L2", ... from launches/query,
+// paginated by the page or offset option. The returned function reports the
+// offsets requested so far.
+func handlePages(t *testing.T, mux *http.ServeMux, total int) func() []int {
+	var (
+		mu      sync.Mutex
+		offsets []int
+	)
+	mux.HandleFunc("/launches/query", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Options struct {
+				Limit  int `json:"limit"`
+				Page   int `json:"page"`
+				Offset int `json:"offset"`
+			} `json:"options"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decoding query: %v", err)
+		}
+		limit, offset := body.Options.Limit, body.Options.Offset
+		if body.Options.Page > 0 {
+			offset = (body.Options.Page - 1) * limit
+		}
+		mu.Lock()
+		offsets = append(offsets, offset)
+		mu.Unlock()
+
+		var docs []string
+		for i := offset; i < min(offset+limit, total); i++ {
+			docs = append(docs, fmt.Sprintf(`{"name":"L%d"}`, i+1))
+		}
+		page := offset/limit + 1
+		hasNext := offset+limit < total
+		next := "null"
+		if hasNext {
+			next = fmt.Sprint(page + 1)
+		}
+		fmt.Fprintf(w, `{"docs":[%s],"totalDocs":%d,"limit":%d,"page":%d,"hasNextPage":%v,"nextPage":%s}`,
+			strings.Join(docs, ","), total, limit, page, hasNext, next)
+	})
+	return func() []int {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]int(nil), offsets...)
+	}
+}
+
+func launchNames(t *testing.T, seq iter.Seq2[*Launch, error]) []string {
+	t.Helper()
+	var names []string
+	for launch, err := range seq {
+		if err != nil {
+			t.Fatalf("Paginate yielded error: %v", err)
+		}
+		names = append(names, launch.Name)
+	}
+	return names
+}
+
+func TestPaginate(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	offsets := handlePages(t, mux, 5)
+
+	q := query.New(nil, &query.Options{Limit: 2})
+	names := launchNames(t, Paginate(context.Background(), client.Launches.QueryLaunches, q, nil))
+
+	if want := []string{"L1", "L2", "L3", "L4", "L5"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Paginate yielded %v, want %v", names, want)
+	}
+	if want := []int{0, 2, 4}; !reflect.DeepEqual(offsets(), want) {
+		t.Errorf("requested offsets %v, want %v", offsets(), want)
+	}
+	if _, ok := q["options"].(*query.Options); !ok || q["options"].(*query.Options).Page != 0 {
+		t.Errorf("Paginate modified the caller's query: %v", q)
+	}
+}
+
+func TestPaginate_Offset(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	offsets := handlePages(t, mux, 5)
+
+	q := query.New(nil, &query.Options{Limit: 2, Offset: 1})
+	names := launchNames(t, Paginate(context.Background(), client.Launches.QueryLaunches, q, nil))
+
+	if want := []string{"L2", "L3", "L4", "L5"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Paginate yielded %v, want %v", names, want)
+	}
+	if want := []int{1, 3}; !reflect.DeepEqual(offsets(), want) {
+		t.Errorf("requested offsets %v, want %v", offsets(), want)
+	}
+}
+
+func TestPaginate_OffsetManyPages(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	offsets := handlePages(t, mux, 8)
+
+	q := query.New(nil, &query.Options{Limit: 2, Offset: 1})
+	names := launchNames(t, Paginate(context.Background(), client.Launches.QueryLaunches, q, nil))
+
+	if want := []string{"L2", "L3", "L4", "L5", "L6", "L7", "L8"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Paginate yielded %v, want %v", names, want)
+	}
+	if want := []int{1, 3, 5, 7}; !reflect.DeepEqual(offsets(), want) {
+		t.Errorf("requested offsets %v, want %v", offsets(), want)
+	}
+}
+
+func TestPaginate_MaxDocs(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	offsets := handlePages(t, mux, 10)
+
+	q := query.New(nil, &query.Options{Limit: 2})
+	opts := &PaginateOptions{MaxDocs: 3}
+	names := launchNames(t, Paginate(context.Background(), client.Launches.QueryLaunches, q, opts))
+
+	if want := []string{"L1", "L2", "L3"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Paginate yielded %v, want %v", names, want)
+	}
+	if want := []int{0, 2}; !reflect.DeepEqual(offsets(), want) {
+		t.Errorf("requested offsets %v, want %v", offsets(), want)
+	}
+}
+
+func TestPaginate_Prefetch(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	offsets := handlePages(t, mux, 6)
+
+	q := query.New(nil, &query.Options{Limit: 3})
+	opts := &PaginateOptions{Prefetch: true}
+	ctx := context.Background()
+	names := launchNames(t, Paginate(ctx, client.Launches.QueryLaunches, q, opts))
+	if want := []string{"L1", "L2", "L3", "L4", "L5", "L6"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Paginate yielded %v, want %v", names, want)
+	}
+
+	// The second page is requested while the first one is consumed.
+	for range Paginate(ctx, client.Launches.QueryLaunches, q, opts) {
+		deadline := time.Now().Add(time.Second)
+		for len(offsets()) < 4 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		break
+	}
+	if want := []int{0, 3, 0, 3}; !reflect.DeepEqual(offsets(), want) {
+		t.Errorf("requested offsets %v, want %v", offsets(), want)
+	}
+}
+
+func TestPaginate_Error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc("/ships/query", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	n := 0
+	for ship, err := range Paginate(context.Background(), client.Ships.QueryShips, nil, nil) {
+		n++
+		if ship != nil || !errors.Is(err, ErrBadRequest) {
+			t.Errorf("Paginate yielded %v, %v; want ErrBadRequest", ship, err)
+		}
+	}
+	if n != 1 {
+		t.Errorf("Paginate yielded %d times, want 1", n)
+	}
+}
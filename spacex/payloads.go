@@ -3,6 +3,9 @@ package spacex
 import (
 	"context"
 	"fmt"
+	"iter"
+
+	"github.com/catdevman/go-spacex/spacex/query"
 )
 
 // PayloadsService handles communication with the payload related
@@ -40,6 +43,15 @@ type Payload struct {
 	ArgOfPericenter *float64       `json:"arg_of_pericenter"`
 	MeanAnomaly     *float64       `json:"mean_anomaly"`
 	Dragon          *DragonPayload `json:"dragon"`
+	ID              string         `json:"id"`
+
+	Document
+}
+
+// MarshalJSON implements json.Marshaler, including the fields in Extra.
+func (p Payload) MarshalJSON() ([]byte, error) {
+	type payload Payload
+	return marshalModel(payload(p), p.Extra)
 }
 
 // DragonPayload represents dragon specific payload info.
@@ -53,36 +65,111 @@ type DragonPayload struct {
 	LandLanding     *bool    `json:"land_landing"`
 }
 
+// PopulatedPayload is a payload whose launch and Dragon capsule may be
+// populated with the documents they refer to. The embedded Payload holds
+// their IDs.
+type PopulatedPayload struct {
+	Payload
+	Launch Ref[Launch]             `json:"launch"`
+	Dragon *PopulatedDragonPayload `json:"dragon"`
+}
+
+// PopulatedDragonPayload is the Dragon capsule information of a payload
+// whose capsule may be populated.
+type PopulatedDragonPayload struct {
+	DragonPayload
+	Capsule Ref[Capsule] `json:"capsule"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting populated and
+// unpopulated references.
+func (p *PopulatedPayload) UnmarshalJSON(data []byte) error {
+	*p = PopulatedPayload{}
+	refs := &struct {
+		Launch *Ref[Launch]             `json:"launch"`
+		Dragon **PopulatedDragonPayload `json:"dragon"`
+	}{&p.Launch, &p.Dragon}
+	err := unmarshalPopulated(data, &p.Payload, refs, []string{"launch"},
+		map[string][]string{"dragon": {"capsule"}})
+	if err != nil {
+		return err
+	}
+
+	// The embedded DragonPayload fields shadowed by the capsule weren't
+	// decoded; take them from the embedded Payload.
+	if p.Dragon != nil && p.Payload.Dragon != nil {
+		p.Dragon.DragonPayload = *p.Payload.Dragon
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, including populated documents.
+func (p PopulatedPayload) MarshalJSON() ([]byte, error) {
+	return marshalPopulated(p.Payload, map[string]interface{}{
+		"launch": p.Launch,
+		"dragon": p.Dragon,
+	})
+}
+
+// PayloadQueryResults represents the result of a payload query.
+type PayloadQueryResults = Page[*Payload]
+
+// PopulatedPayloadQueryResults represents the result of a populated payload query.
+type PopulatedPayloadQueryResults = Page[*PopulatedPayload]
+
 // ListAllPayloads lists all payloads.
-func (s *PayloadsService) ListAllPayloads(ctx context.Context) ([]*Payload, error) {
+func (s *PayloadsService) ListAllPayloads(ctx context.Context, opts ...CallOption) ([]*Payload, *Response, error) {
 	u := "payloads"
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var payloads []*Payload
-	_, err = s.client.do(ctx, req, &payloads)
+	resp, err := s.client.do(ctx, req, &payloads)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return payloads, nil
+	return payloads, resp, nil
+}
+
+// All iterates over all payloads, decoding them one at a time.
+func (s *PayloadsService) All(ctx context.Context, opts ...CallOption) iter.Seq2[*Payload, error] {
+	return streamList[Payload](ctx, s.client, "payloads", opts...)
 }
 
 // GetPayload retrieves a specific payload.
-func (s *PayloadsService) GetPayload(ctx context.Context, id string) (*Payload, error) {
+func (s *PayloadsService) GetPayload(ctx context.Context, id string, opts ...CallOption) (*Payload, *Response, error) {
 	u := fmt.Sprintf("payloads/%s", id)
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	payload := new(Payload)
-	_, err = s.client.do(ctx, req, payload)
+	resp, err := s.client.do(ctx, req, payload)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return payload, nil
+	return payload, resp, nil
+}
+
+// GetMany retrieves the payloads with the given IDs, in order. IDs that
+// don't exist have nil entries and are reported in a *MissingIDsError.
+func (s *PayloadsService) GetMany(ctx context.Context, ids []string, opts ...CallOption) ([]*Payload, *Response, error) {
+	return getMany(ctx, s.client, "payloads", ids, func(p *Payload) string { return p.ID }, opts)
+}
+
+// QueryPayloads queries for payloads. The query can be built with the query
+// package.
+func (s *PayloadsService) QueryPayloads(ctx context.Context, query map[string]interface{}, opts ...CallOption) (*PayloadQueryResults, *Response, error) {
+	return queryPage[*Payload](ctx, s.client, "payloads", query, opts)
+}
+
+// QueryPayloadsPopulated queries for payloads like QueryPayloads,
+// additionally populating their launch and Dragon capsule.
+func (s *PayloadsService) QueryPayloadsPopulated(ctx context.Context, q map[string]interface{}, opts ...CallOption) (*PopulatedPayloadQueryResults, *Response, error) {
+	return queryPage[*PopulatedPayload](ctx, s.client, "payloads", query.Query(q).Populate("launch", "dragon.capsule"), opts)
 }
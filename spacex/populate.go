@@ -0,0 +1,178 @@
+package spacex
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Ref is a reference to a document of type T, such as the rocket of a
+// launch. The API returns references as IDs, unless they are populated with
+// the populate query option, in which case the referenced document is
+// embedded and decoded into Doc.
+type Ref[T any] struct {
+	ID  string `json:"-"`
+	Doc *T     `json:"-"` // nil unless populated
+}
+
+// Populated reports whether the referenced document was embedded.
+func (r Ref[T]) Populated() bool {
+	return r.Doc != nil
+}
+
+func (r *Ref[T]) doc() interface{} {
+	if r.Doc == nil {
+		return nil
+	}
+	return r.Doc
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting an ID, a document or
+// null.
+func (r *Ref[T]) UnmarshalJSON(data []byte) error {
+	*r = Ref[T]{}
+	switch firstByte(data) {
+	case 'n':
+		return nil
+	case '{':
+		var ident struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(data, &ident); err != nil {
+			return err
+		}
+		r.ID = ident.ID
+		r.Doc = new(T)
+		return json.Unmarshal(data, r.Doc)
+	}
+	return json.Unmarshal(data, &r.ID)
+}
+
+// MarshalJSON implements json.Marshaler, encoding the document if populated
+// and the ID otherwise.
+func (r Ref[T]) MarshalJSON() ([]byte, error) {
+	switch {
+	case r.Doc != nil:
+		return json.Marshal(r.Doc)
+	case r.ID != "":
+		return json.Marshal(r.ID)
+	}
+	return []byte("null"), nil
+}
+
+// firstByte returns the first non-space byte of data.
+func firstByte(data []byte) byte {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return 0
+	}
+	return data[0]
+}
+
+// unmarshalPopulated decodes data, a document whose references may be
+// populated, into refs, and into model with the references replaced by their
+// IDs. keys lists the reference fields of the document and nested the
+// reference fields of its sub-documents, such as the core and landpad of
+// each element of "cores".
+func unmarshalPopulated(data []byte, model, refs interface{}, keys []string, nested map[string][]string) error {
+	if err := json.Unmarshal(data, refs); err != nil {
+		return err
+	}
+	plain, err := depopulate(data, keys, nested)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(plain, model)
+}
+
+// depopulate returns the document data with the populated references at
+// keys and nested replaced by their IDs.
+func depopulate(data []byte, keys []string, nested map[string][]string) ([]byte, error) {
+	if firstByte(data) != '{' {
+		return data, nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	var err error
+	for _, k := range keys {
+		if raw, ok := fields[k]; ok {
+			if fields[k], err = refIDs(raw); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for k, subKeys := range nested {
+		raw, ok := fields[k]
+		if !ok {
+			continue
+		}
+		if firstByte(raw) == '[' {
+			var elems []json.RawMessage
+			if err := json.Unmarshal(raw, &elems); err != nil {
+				return nil, err
+			}
+			for i, el := range elems {
+				if elems[i], err = depopulate(el, subKeys, nil); err != nil {
+					return nil, err
+				}
+			}
+			fields[k], err = json.Marshal(elems)
+		} else {
+			fields[k], err = depopulate(raw, subKeys, nil)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// refIDs returns raw, a reference or an array of references, with populated
+// documents replaced by their IDs.
+func refIDs(raw json.RawMessage) (json.RawMessage, error) {
+	switch firstByte(raw) {
+	case '{':
+		var ident struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(raw, &ident); err != nil {
+			return nil, err
+		}
+		return json.Marshal(ident.ID)
+	case '[':
+		var elems []json.RawMessage
+		if err := json.Unmarshal(raw, &elems); err != nil {
+			return nil, err
+		}
+		for i, el := range elems {
+			id, err := refIDs(el)
+			if err != nil {
+				return nil, err
+			}
+			elems[i] = id
+		}
+		return json.Marshal(elems)
+	}
+	return raw, nil
+}
+
+// marshalPopulated encodes model with the fields in refs replaced by the
+// possibly populated references.
+func marshalPopulated(model interface{}, refs map[string]interface{}) ([]byte, error) {
+	data, err := json.Marshal(model)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for k, v := range refs {
+		if fields[k], err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(fields)
+}
@@ -0,0 +1,171 @@
+package spacex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/catdevman/go-spacex/spacex/query"
+)
+
+const populatedLaunchJSON = `{
+	"name": "CRS-20",
+	"rocket": {"id": "5e9d0d95eda69973a809d1ec", "name": "Falcon 9"},
+	"launchpad": "5e9e4501f509094ba4566f84",
+	"payloads": [{"id": "5eb0e4d0b6c3bb0006eeb253", "name": "CRS-20", "type": "Dragon 1.1"}, "5eb0e4d0b6c3bb0006eeb254"],
+	"crew": [],
+	"cores": [{"core": {"id": "5e9e28a6f35918c0803b265c", "serial": "B1059"}, "flight": 3, "landpad": "5e9e3033383ecbb9e534e7cc"}],
+	"new_field": true,
+	"id": "5eb87d42ffd86e000604b384"
+}`
+
+func TestPopulatedLaunch_UnmarshalJSON(t *testing.T) {
+	launch := new(PopulatedLaunch)
+	if err := UnmarshalDocument([]byte(populatedLaunchJSON), launch); err != nil {
+		t.Fatalf("UnmarshalDocument returned error: %v", err)
+	}
+
+	if launch.Name != "CRS-20" || launch.ID != "5eb87d42ffd86e000604b384" {
+		t.Errorf("Launch = %q %q, want CRS-20 5eb87d42ffd86e000604b384", launch.Name, launch.ID)
+	}
+	if !launch.Rocket.Populated() || launch.Rocket.ID != "5e9d0d95eda69973a809d1ec" || launch.Rocket.Doc.Name != "Falcon 9" {
+		t.Errorf("Rocket = %+v, want populated Falcon 9", launch.Rocket)
+	}
+	if launch.Launch.Rocket == nil || *launch.Launch.Rocket != "5e9d0d95eda69973a809d1ec" {
+		t.Errorf("Launch.Rocket = %v, want the rocket ID", launch.Launch.Rocket)
+	}
+	if launch.Launchpad.Populated() || launch.Launchpad.ID != "5e9e4501f509094ba4566f84" {
+		t.Errorf("Launchpad = %+v, want unpopulated ID", launch.Launchpad)
+	}
+
+	if len(launch.Payloads) != 2 || !launch.Payloads[0].Populated() || launch.Payloads[1].Populated() {
+		t.Fatalf("Payloads = %+v, want one populated and one ID", launch.Payloads)
+	}
+	if got := *launch.Payloads[0].Doc.Type; got != "Dragon 1.1" {
+		t.Errorf("Payloads[0].Doc.Type = %q, want %q", got, "Dragon 1.1")
+	}
+	if want := []string{"5eb0e4d0b6c3bb0006eeb253", "5eb0e4d0b6c3bb0006eeb254"}; fmt.Sprint(launch.Launch.Payloads) != fmt.Sprint(want) {
+		t.Errorf("Launch.Payloads = %v, want %v", launch.Launch.Payloads, want)
+	}
+
+	if len(launch.Cores) != 1 {
+		t.Fatalf("Cores = %+v, want 1 core", launch.Cores)
+	}
+	core := launch.Cores[0]
+	if core.Core.Doc == nil || core.Core.Doc.Serial != "B1059" || core.Landpad.ID != "5e9e3033383ecbb9e534e7cc" {
+		t.Errorf("Cores[0] = %+v, want populated B1059 and landpad ID", core)
+	}
+	if core.Flight == nil || *core.Flight != 3 || core.CoreLaunch.Core == nil || *core.CoreLaunch.Core != "5e9e28a6f35918c0803b265c" {
+		t.Errorf("Cores[0].CoreLaunch = %+v, want flight 3 and the core ID", core.CoreLaunch)
+	}
+
+	if _, ok := launch.Extra["new_field"]; !ok || len(launch.Extra) != 1 {
+		t.Errorf("Extra = %v, want only new_field", launch.Extra)
+	}
+	if string(launch.Raw()) != populatedLaunchJSON {
+		t.Errorf("Raw() = %s, want the original document", launch.Raw())
+	}
+}
+
+func TestPopulatedLaunch_MarshalJSON(t *testing.T) {
+	launch := new(PopulatedLaunch)
+	if err := UnmarshalDocument([]byte(populatedLaunchJSON), launch); err != nil {
+		t.Fatalf("UnmarshalDocument returned error: %v", err)
+	}
+	out, err := json.Marshal(launch)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+
+	again := new(PopulatedLaunch)
+	if err := UnmarshalDocument(out, again); err != nil {
+		t.Fatalf("json.Unmarshal of %s returned error: %v", out, err)
+	}
+	if again.Rocket.Doc == nil || again.Rocket.Doc.Name != "Falcon 9" || again.Launchpad.ID != launch.Launchpad.ID ||
+		again.Cores[0].Core.Doc == nil || again.Cores[0].Core.Doc.Serial != "B1059" {
+		t.Errorf("round trip produced %s", out)
+	}
+	if _, ok := again.Extra["new_field"]; !ok {
+		t.Errorf("round trip lost new_field: %s", out)
+	}
+}
+
+func TestPopulatedPayload_UnmarshalJSON(t *testing.T) {
+	doc := `{"name":"CRS-20","launch":{"id":"l1","name":"CRS-20"},"dragon":{"capsule":{"id":"c1","serial":"C112"},"water_landing":true},"id":"p1"}`
+	payload := new(PopulatedPayload)
+	if err := json.Unmarshal([]byte(doc), payload); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+	if payload.Launch.Doc == nil || payload.Launch.Doc.Name != "CRS-20" || *payload.Payload.Launch != "l1" {
+		t.Errorf("Launch = %+v, want populated CRS-20", payload.Launch)
+	}
+	if payload.Dragon == nil || payload.Dragon.Capsule.Doc == nil || payload.Dragon.Capsule.Doc.Serial != "C112" {
+		t.Fatalf("Dragon = %+v, want populated capsule C112", payload.Dragon)
+	}
+	if payload.Dragon.WaterLanding == nil || !*payload.Dragon.WaterLanding || *payload.Dragon.DragonPayload.Capsule != "c1" {
+		t.Errorf("Dragon.DragonPayload = %+v, want water landing and capsule ID", payload.Dragon.DragonPayload)
+	}
+}
+
+func TestPopulatedCore_UnpopulatedLaunches(t *testing.T) {
+	core := new(PopulatedCore)
+	if err := json.Unmarshal([]byte(`{"serial":"B1049","launches":["l1","l2"]}`), core); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+	if len(core.Launches) != 2 || core.Launches[0].Populated() || core.Launches[1].ID != "l2" || core.Serial != "B1049" {
+		t.Errorf("PopulatedCore = %+v, want unpopulated launches l1, l2", core)
+	}
+}
+
+func TestLaunchesService_QueryLaunchesPopulated(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/launches/query", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var q struct {
+			Options struct {
+				Limit    int              `json:"limit"`
+				Populate []query.Populate `json:"populate"`
+			} `json:"options"`
+		}
+		if err := json.Unmarshal(body, &q); err != nil {
+			t.Fatalf("decoding query: %v", err)
+		}
+		var paths []string
+		for _, p := range q.Options.Populate {
+			paths = append(paths, p.Path)
+		}
+		if got, want := strings.Join(paths, " "), strings.Join(launchRefs, " "); q.Options.Limit != 1 || got != want {
+			t.Errorf("query options = %s, want limit 1 and populate %s", body, want)
+		}
+		fmt.Fprintf(w, `{"docs":[%s],"totalDocs":1}`, populatedLaunchJSON)
+	})
+
+	ctx := context.Background()
+	q := query.New(nil, &query.Options{Limit: 1})
+	results, _, err := client.Launches.QueryLaunchesPopulated(ctx, q)
+	if err != nil {
+		t.Fatalf("Launches.QueryLaunchesPopulated returned error: %v", err)
+	}
+	if len(results.Docs) != 1 || results.Docs[0].Rocket.Doc == nil || results.Docs[0].Rocket.Doc.Name != "Falcon 9" {
+		t.Errorf("Launches.QueryLaunchesPopulated returned %+v", results.Docs)
+	}
+	if len(q["options"].(*query.Options).Populate) != 0 {
+		t.Errorf("Launches.QueryLaunchesPopulated modified the caller's query")
+	}
+}
+
+func TestLaunchesService_QueryLaunchesPopulatedInvalidOptions(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	q := map[string]interface{}{"options": "limit"}
+	if _, _, err := client.Launches.QueryLaunchesPopulated(context.Background(), q); err == nil {
+		t.Error("Launches.QueryLaunchesPopulated returned no error for invalid options")
+	}
+}
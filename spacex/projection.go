@@ -0,0 +1,73 @@
+package spacex
+
+import (
+	"context"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// QueryInto queries resource, such as "launches", decoding the matching
+// documents into T instead of the package's own types. Combined with the
+// select option it fetches only the fields the caller uses:
+//
+//	type launch struct {
+//		Name    string    `json:"name"`
+//		DateUTC time.Time `json:"date_utc"`
+//		Success *bool     `json:"success"`
+//	}
+//	q := query.New(query.Eq("upcoming", false), nil).Select(spacex.SelectFields[launch]()...)
+//	results, _, err := spacex.QueryInto[launch](ctx, client, "launches", q)
+func QueryInto[T any](ctx context.Context, c *Client, resource string, query map[string]interface{}, opts ...CallOption) (*Page[T], *Response, error) {
+	return queryPage[T](ctx, c, resource, query, opts)
+}
+
+// SelectFields returns the JSON names of the fields of T, a struct or a
+// pointer to one, in sorted order, for use as the select option of a query.
+// Fields of embedded structs are included and fields tagged "-" left out.
+// Untagged fields are selected by their lowercased Go name, as the API's
+// fields are lowercase, so Name selects "name"; tag fields with other names,
+// such as date_utc. It returns nil, which selects every field, if T is not a
+// struct.
+func SelectFields[T any]() []string {
+	t := reflect.TypeFor[T]()
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	fields := make(map[string]bool)
+	selectFields(t, fields)
+	return slices.Sorted(maps.Keys(fields))
+}
+
+// selectFields adds the names SelectFields selects for struct type t to
+// fields.
+func selectFields(t reflect.Type, fields map[string]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, ok := jsonName(f)
+		if !ok {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				selectFields(ft, fields)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = true
+	}
+}
@@ -0,0 +1,92 @@
+package spacex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/catdevman/go-spacex/spacex/query"
+)
+
+type launchSummary struct {
+	Name    string    `json:"name"`
+	DateUTC time.Time `json:"date_utc"`
+	Success *bool     `json:"success"`
+	Note    string    `json:"-"`
+}
+
+func TestQueryInto(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/launches/query", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Request method = %v, want %v", r.Method, "POST")
+		}
+		var body struct {
+			Options struct {
+				Select string `json:"select"`
+			} `json:"options"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding query: %v", err)
+		}
+		if want := "date_utc name success"; body.Options.Select != want {
+			t.Errorf("select = %q, want %q", body.Options.Select, want)
+		}
+		fmt.Fprint(w, `{"docs":[{"name":"CRS-20","date_utc":"2020-03-07T04:50:31.000Z","success":true}],"totalDocs":1}`)
+	})
+
+	q := query.New(query.Eq("upcoming", false), nil).Select(SelectFields[launchSummary]()...)
+	results, _, err := QueryInto[launchSummary](context.Background(), client, "launches", q)
+	if err != nil {
+		t.Fatalf("QueryInto returned error: %v", err)
+	}
+
+	success := true
+	want := &Page[launchSummary]{
+		Docs: []launchSummary{{
+			Name:    "CRS-20",
+			DateUTC: time.Date(2020, 3, 7, 4, 50, 31, 0, time.UTC),
+			Success: &success,
+		}},
+		TotalDocs: 1,
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("QueryInto returned %+v, want %+v", results, want)
+	}
+}
+
+func TestSelectFields(t *testing.T) {
+	type base struct {
+		ID string `json:"id"`
+	}
+	type untagged struct {
+		Name    string
+		Success *bool `json:"success"`
+	}
+	type withEmbed struct {
+		base
+		Flight int `json:"flight_number,omitempty"`
+	}
+
+	tests := []struct {
+		got  []string
+		want []string
+	}{
+		{SelectFields[launchSummary](), []string{"date_utc", "name", "success"}},
+		{SelectFields[*launchSummary](), []string{"date_utc", "name", "success"}},
+		{SelectFields[withEmbed](), []string{"flight_number", "id"}},
+		{SelectFields[untagged](), []string{"name", "success"}},
+		{SelectFields[string](), nil},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("SelectFields returned %v, want %v", tt.got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package query
+
+// Eq matches records whose field equals value.
+func Eq(field string, value interface{}) Filter {
+	return op(field, "$eq", value)
+}
+
+// Ne matches records whose field doesn't equal value.
+func Ne(field string, value interface{}) Filter {
+	return op(field, "$ne", value)
+}
+
+// In matches records whose field equals one of values.
+func In[T any](field string, values ...T) Filter {
+	if values == nil {
+		values = []T{}
+	}
+	return op(field, "$in", values)
+}
+
+// Nin matches records whose field equals none of values.
+func Nin[T any](field string, values ...T) Filter {
+	if values == nil {
+		values = []T{}
+	}
+	return op(field, "$nin", values)
+}
+
+// Gt matches records whose field is greater than value.
+func Gt(field string, value interface{}) Filter {
+	return op(field, "$gt", value)
+}
+
+// Gte matches records whose field is greater than or equal to value.
+func Gte(field string, value interface{}) Filter {
+	return op(field, "$gte", value)
+}
+
+// Lt matches records whose field is less than value.
+func Lt(field string, value interface{}) Filter {
+	return op(field, "$lt", value)
+}
+
+// Lte matches records whose field is less than or equal to value.
+func Lte(field string, value interface{}) Filter {
+	return op(field, "$lte", value)
+}
+
+// Exists matches records that have field if exists is true, and records
+// that don't if it is false.
+func Exists(field string, exists bool) Filter {
+	return op(field, "$exists", exists)
+}
+
+// Regex matches records whose field matches the regular expression pattern.
+// options holds MongoDB regular expression flags, such as "i" for case
+// insensitive matching.
+func Regex(field, pattern, options string) Filter {
+	expr := map[string]interface{}{"$regex": pattern}
+	if options != "" {
+		expr["$options"] = options
+	}
+	return Filter{field: expr}
+}
+
+// And matches records matching all of filters. With no filters, it matches
+// all records.
+func And(filters ...Filter) Filter {
+	switch len(filters) {
+	case 0:
+		return Filter{}
+	case 1:
+		return filters[0]
+	}
+	return Filter{"$and": filters}
+}
+
+// Or matches records matching any of filters. With no filters, it matches
+// no records.
+func Or(filters ...Filter) Filter {
+	switch len(filters) {
+	case 0:
+		return Not(Filter{})
+	case 1:
+		return filters[0]
+	}
+	return Filter{"$or": filters}
+}
+
+// Not matches records that don't match filter.
+func Not(filter Filter) Filter {
+	return Filter{"$nor": []Filter{filter}}
+}
+
+func op(field, operator string, value interface{}) Filter {
+	return Filter{field: map[string]interface{}{operator: value}}
+}
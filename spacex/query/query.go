@@ -0,0 +1,239 @@
+// Package query builds the bodies of the SpaceX API query endpoints, such as
+// launches/query, from composable filters instead of hand-written
+// MongoDB-style maps.
+//
+// Usage:
+//
+//	q := query.New(
+//		query.And(query.Eq("upcoming", false), query.Gte("date_utc", "2020-01-01")),
+//		&query.Options{Sort: query.Fields{"-date_utc"}, Limit: 10},
+//	)
+//	results, _, err := client.Launches.QueryLaunches(ctx, q)
+//
+// A Query is a map[string]interface{}, so it is accepted by every query
+// method of the spacex package.
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Query is the body of a query request, holding a filter under "query" and
+// Options under "options".
+type Query map[string]interface{}
+
+// Filter is a MongoDB-style filter document matching the records to return.
+type Filter map[string]interface{}
+
+// New returns a Query for the records matching filter. A nil filter matches
+// all records; nil options use the API's defaults.
+func New(filter Filter, options *Options) Query {
+	if filter == nil {
+		filter = Filter{}
+	}
+	q := Query{"query": filter}
+	if options != nil {
+		q["options"] = options
+	}
+	return q
+}
+
+// Populate returns a copy of q that also populates each of paths, such as
+// "rocket" or "cores.core". Paths that q already populates are left as they
+// are. Options of other types than Options and maps are converted through
+// their JSON encoding; if that isn't an object, marshalling the returned
+// query fails.
+func (q Query) Populate(paths ...string) Query {
+	out := maps.Clone(q)
+	if out == nil {
+		out = Query{"query": Filter{}}
+	}
+
+	switch o := q["options"].(type) {
+	case nil:
+		out["options"] = &Options{Populate: Paths(paths...)}
+	case *Options:
+		c := *o
+		c.Populate = appendPaths(slices.Clone(c.Populate), paths)
+		out["options"] = &c
+	case Options:
+		o.Populate = appendPaths(slices.Clone(o.Populate), paths)
+		out["options"] = &o
+	case map[string]interface{}:
+		out["options"] = populateMap(maps.Clone(o), paths)
+	default:
+		c, err := optionsMap(o)
+		if err != nil {
+			out["options"] = invalidOptions{err}
+		} else {
+			out["options"] = populateMap(c, paths)
+		}
+	}
+	return out
+}
+
+// populateMap adds the paths not populated yet to the populate option of c,
+// options given as a map.
+func populateMap(c map[string]interface{}, paths []string) map[string]interface{} {
+	var populate []interface{}
+	have := make(map[string]bool)
+	switch p := c["populate"].(type) {
+	case nil:
+	case string:
+		for _, path := range strings.Fields(p) {
+			populate = append(populate, path)
+		}
+	case []interface{}:
+		populate = append(populate, p...)
+	default:
+		populate = append(populate, p)
+	}
+	for _, p := range populate {
+		switch p := p.(type) {
+		case string:
+			have[p] = true
+		case map[string]interface{}:
+			if path, ok := p["path"].(string); ok {
+				have[path] = true
+			}
+		case Populate:
+			have[p.Path] = true
+		}
+	}
+	for _, path := range paths {
+		if !have[path] {
+			populate = append(populate, Populate{Path: path})
+		}
+	}
+	c["populate"] = populate
+	return c
+}
+
+// optionsMap converts options of any other type than Options or a map to a
+// map through their JSON encoding, which must be an object.
+func optionsMap(options interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(options)
+	if err != nil {
+		return nil, fmt.Errorf("query: invalid options: %w", err)
+	}
+	var c map[string]interface{}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("query: options must be a JSON object, got %s", data)
+	}
+	if c == nil {
+		c = make(map[string]interface{})
+	}
+	return c, nil
+}
+
+// invalidOptions replaces options that could not be changed, reporting why
+// when the query is marshalled, and so sent.
+type invalidOptions struct {
+	err error
+}
+
+// MarshalJSON implements json.Marshaler.
+func (o invalidOptions) MarshalJSON() ([]byte, error) {
+	return nil, o.err
+}
+
+// appendPaths appends the paths not in populate yet.
+func appendPaths(populate []Populate, paths []string) []Populate {
+	for _, path := range paths {
+		if !slices.ContainsFunc(populate, func(p Populate) bool { return p.Path == path }) {
+			populate = append(populate, Populate{Path: path})
+		}
+	}
+	return populate
+}
+
+// Select returns a copy of q that returns only fields, replacing the select
+// option of q. Options of other types are handled as by Populate.
+func (q Query) Select(fields ...string) Query {
+	out := maps.Clone(q)
+	if out == nil {
+		out = Query{"query": Filter{}}
+	}
+
+	switch o := q["options"].(type) {
+	case nil:
+		out["options"] = &Options{Select: fields}
+	case *Options:
+		c := *o
+		c.Select = fields
+		out["options"] = &c
+	case Options:
+		o.Select = fields
+		out["options"] = &o
+	case map[string]interface{}:
+		c := maps.Clone(o)
+		c["select"] = Fields(fields)
+		out["options"] = c
+	default:
+		c, err := optionsMap(o)
+		if err != nil {
+			out["options"] = invalidOptions{err}
+		} else {
+			c["select"] = Fields(fields)
+			out["options"] = c
+		}
+	}
+	return out
+}
+
+// Options controls the selection, order and pagination of query results.
+type Options struct {
+	// Select lists the fields to return, or, prefixed with "-", to leave
+	// out.
+	Select Fields `json:"select,omitempty"`
+
+	// Sort lists the fields to sort by, prefixed with "-" for descending
+	// order.
+	Sort Fields `json:"sort,omitempty"`
+
+	Offset int `json:"offset,omitempty"`
+	Page   int `json:"page,omitempty"`
+	Limit  int `json:"limit,omitempty"`
+
+	// Pagination, if set to false, returns all matching records at once.
+	Pagination *bool `json:"pagination,omitempty"`
+
+	// Populate replaces the IDs in the listed fields by the records they
+	// reference.
+	Populate []Populate `json:"populate,omitempty"`
+}
+
+// Fields is a list of field names, serialized space-separated as expected
+// by the select and sort options.
+type Fields []string
+
+// MarshalJSON implements json.Marshaler.
+func (f Fields) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strings.Join(f, " "))
+}
+
+// Populate describes a field to populate, optionally selecting the fields
+// of the referenced records and populating their fields in turn.
+type Populate struct {
+	Path     string     `json:"path"`
+	Select   Fields     `json:"select,omitempty"`
+	Populate []Populate `json:"populate,omitempty"`
+}
+
+// Paths returns Populate options populating each of paths.
+func Paths(paths ...string) []Populate {
+	populate := make([]Populate, len(paths))
+	for i, p := range paths {
+		populate[i] = Populate{Path: p}
+	}
+	return populate
+}
+
+// Bool returns a pointer to v, for Options.Pagination.
+func Bool(v bool) *bool {
+	return &v
+}
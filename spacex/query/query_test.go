@@ -0,0 +1,149 @@
+package query
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func testJSON(t *testing.T, v interface{}, want string) {
+	t.Helper()
+	got, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+	var gotV, wantV interface{}
+	json.Unmarshal(got, &gotV)
+	if err := json.Unmarshal([]byte(want), &wantV); err != nil {
+		t.Fatalf("invalid want JSON %s: %v", want, err)
+	}
+	g, _ := json.Marshal(gotV)
+	w, _ := json.Marshal(wantV)
+	if string(g) != string(w) {
+		t.Errorf("JSON = %s, want %s", got, want)
+	}
+}
+
+func TestFilters(t *testing.T) {
+	tests := []struct {
+		filter Filter
+		want   string
+	}{
+		{Eq("success", true), `{"success":{"$eq":true}}`},
+		{Ne("rocket", "abc"), `{"rocket":{"$ne":"abc"}}`},
+		{In("_id", "a", "b"), `{"_id":{"$in":["a","b"]}}`},
+		{In[string]("_id"), `{"_id":{"$in":[]}}`},
+		{Nin("flight_number", 1, 2), `{"flight_number":{"$nin":[1,2]}}`},
+		{Gt("flight_number", 100), `{"flight_number":{"$gt":100}}`},
+		{Gte("date_utc", "2020-01-01"), `{"date_utc":{"$gte":"2020-01-01"}}`},
+		{Lt("mass_kg", 500), `{"mass_kg":{"$lt":500}}`},
+		{Lte("mass_kg", 500), `{"mass_kg":{"$lte":500}}`},
+		{Exists("crew.0", true), `{"crew.0":{"$exists":true}}`},
+		{Regex("name", "^starlink", "i"), `{"name":{"$regex":"^starlink","$options":"i"}}`},
+		{Regex("name", "Falcon", ""), `{"name":{"$regex":"Falcon"}}`},
+		{Not(Eq("upcoming", true)), `{"$nor":[{"upcoming":{"$eq":true}}]}`},
+		{And(), `{}`},
+		{And(Eq("upcoming", false)), `{"upcoming":{"$eq":false}}`},
+		{
+			And(Eq("upcoming", false), Or(Eq("success", false), Exists("failures.0", true))),
+			`{"$and":[{"upcoming":{"$eq":false}},{"$or":[{"success":{"$eq":false}},{"failures.0":{"$exists":true}}]}]}`,
+		},
+		{Or(), `{"$nor":[{}]}`},
+	}
+	for _, tt := range tests {
+		testJSON(t, tt.filter, tt.want)
+	}
+}
+
+func TestNew(t *testing.T) {
+	q := New(Eq("upcoming", true), &Options{
+		Select:     Fields{"name", "date_utc", "-links"},
+		Sort:       Fields{"-date_utc"},
+		Offset:     5,
+		Page:       2,
+		Limit:      10,
+		Pagination: Bool(false),
+		Populate: []Populate{
+			{Path: "rocket", Select: Fields{"name"}},
+			{Path: "payloads", Populate: Paths("customers")},
+		},
+	})
+	testJSON(t, q, `{
+		"query": {"upcoming": {"$eq": true}},
+		"options": {
+			"select": "name date_utc -links",
+			"sort": "-date_utc",
+			"offset": 5,
+			"page": 2,
+			"limit": 10,
+			"pagination": false,
+			"populate": [
+				{"path": "rocket", "select": "name"},
+				{"path": "payloads", "populate": [{"path": "customers"}]}
+			]
+		}
+	}`)
+
+	testJSON(t, New(nil, nil), `{"query":{}}`)
+	testJSON(t, New(nil, &Options{}), `{"query":{},"options":{}}`)
+}
+
+func TestQuery_Populate(t *testing.T) {
+	testJSON(t, New(nil, nil).Populate("rocket"), `{"query":{},"options":{"populate":[{"path":"rocket"}]}}`)
+	testJSON(t, Query(nil).Populate("rocket"), `{"query":{},"options":{"populate":[{"path":"rocket"}]}}`)
+
+	options := &Options{Limit: 5, Populate: Paths("rocket")}
+	q := New(Eq("upcoming", true), options)
+	testJSON(t, q.Populate("rocket", "crew"), `{
+		"query": {"upcoming": {"$eq": true}},
+		"options": {"limit": 5, "populate": [{"path": "rocket"}, {"path": "crew"}]}
+	}`)
+	if len(options.Populate) != 1 {
+		t.Errorf("Populate modified the original options: %v", options.Populate)
+	}
+
+	m := Query{"options": map[string]interface{}{"populate": "rocket launchpad"}}
+	testJSON(t, m.Populate("launchpad", "payloads"), `{
+		"options": {"populate": ["rocket", "launchpad", {"path": "payloads"}]}
+	}`)
+	testJSON(t, m, `{"options":{"populate":"rocket launchpad"}}`)
+
+	typed := Query{"options": Query{"limit": 1}}
+	testJSON(t, typed.Populate("rocket"), `{"options":{"limit":1,"populate":[{"path":"rocket"}]}}`)
+	testJSON(t, typed, `{"options":{"limit":1}}`)
+}
+
+func TestQuery_PopulateInvalidOptions(t *testing.T) {
+	for _, options := range []interface{}{"limit", []int{1}, func() {}} {
+		q := Query{"options": options}.Populate("rocket")
+		if _, err := json.Marshal(q); err == nil {
+			t.Errorf("Populate with options %T: json.Marshal returned no error", options)
+		}
+	}
+}
+
+func TestQuery_Select(t *testing.T) {
+	testJSON(t, Query(nil).Select("name"), `{"query":{},"options":{"select":"name"}}`)
+
+	options := &Options{Limit: 5, Select: Fields{"-links"}}
+	q := New(nil, options)
+	testJSON(t, q.Select("name", "date_utc"), `{"query":{},"options":{"select":"name date_utc","limit":5}}`)
+	if len(options.Select) != 1 {
+		t.Errorf("Select modified the original options: %v", options.Select)
+	}
+
+	m := Query{"options": map[string]interface{}{"limit": 1}}
+	testJSON(t, m.Select("name"), `{"options":{"limit":1,"select":"name"}}`)
+
+	typed := Query{"options": Query{"limit": 1}}
+	testJSON(t, typed.Select("name"), `{"options":{"limit":1,"select":"name"}}`)
+	testJSON(t, typed, `{"options":{"limit":1}}`)
+}
+
+func TestQuery_SelectInvalidOptions(t *testing.T) {
+	for _, options := range []interface{}{"limit", []int{1}, func() {}} {
+		q := Query{"options": options}.Select("name")
+		if _, err := json.Marshal(q); err == nil {
+			t.Errorf("Select with options %T: json.Marshal returned no error", options)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package spacex
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/catdevman/go-spacex/spacex/query"
+)
+
+// testQuery is the query sent by the query method tests.
+var testQuery = query.New(query.Eq("status", "active"), &query.Options{Limit: 1})
+
+// handleQuery registers a handler for the query endpoint at path checking
+// the request and answering with a page holding doc.
+func handleQuery(t *testing.T, mux *http.ServeMux, path, doc string) {
+	t.Helper()
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Request method = %v, want %v", r.Method, "POST")
+		}
+		body, _ := io.ReadAll(r.Body)
+		if want := `{"options":{"limit":1},"query":{"status":{"$eq":"active"}}}` + "\n"; string(body) != want {
+			t.Errorf("Request body = %s, want %s", body, want)
+		}
+		fmt.Fprintf(w, `{"docs":[%s],"totalDocs":1,"limit":1,"totalPages":1,"page":1,"pagingCounter":1,"hasPrevPage":false,"hasNextPage":false,"prevPage":null,"nextPage":null}`, doc)
+	})
+}
+
+func TestCapsulesService_QueryCapsules(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	handleQuery(t, mux, "/capsules/query", `{"serial":"C101"}`)
+
+	ctx := context.Background()
+	results, _, err := client.Capsules.QueryCapsules(ctx, testQuery)
+	if err != nil {
+		t.Fatalf("Capsules.QueryCapsules returned error: %v", err)
+	}
+	if results.TotalDocs != 1 || len(results.Docs) != 1 || results.Docs[0].Serial != "C101" {
+		t.Errorf("Capsules.QueryCapsules returned %+v", results)
+	}
+}
+
+func TestCoresService_QueryCores(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	handleQuery(t, mux, "/cores/query", `{"serial":"B1049"}`)
+
+	ctx := context.Background()
+	results, _, err := client.Cores.QueryCores(ctx, testQuery)
+	if err != nil {
+		t.Fatalf("Cores.QueryCores returned error: %v", err)
+	}
+	if results.TotalDocs != 1 || len(results.Docs) != 1 || results.Docs[0].Serial != "B1049" {
+		t.Errorf("Cores.QueryCores returned %+v", results)
+	}
+}
+
+func TestCrewService_QueryCrew(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	handleQuery(t, mux, "/crew/query", `{"name":"Bob Behnken"}`)
+
+	ctx := context.Background()
+	results, _, err := client.Crew.QueryCrew(ctx, testQuery)
+	if err != nil {
+		t.Fatalf("Crew.QueryCrew returned error: %v", err)
+	}
+	if results.TotalDocs != 1 || len(results.Docs) != 1 || *results.Docs[0].Name != "Bob Behnken" {
+		t.Errorf("Crew.QueryCrew returned %+v", results)
+	}
+}
+
+func TestLandpadsService_QueryLandpads(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	handleQuery(t, mux, "/landpads/query", `{"name":"LZ-1"}`)
+
+	ctx := context.Background()
+	results, _, err := client.Landpads.QueryLandpads(ctx, testQuery)
+	if err != nil {
+		t.Fatalf("Landpads.QueryLandpads returned error: %v", err)
+	}
+	if results.TotalDocs != 1 || len(results.Docs) != 1 || *results.Docs[0].Name != "LZ-1" {
+		t.Errorf("Landpads.QueryLandpads returned %+v", results)
+	}
+}
+
+func TestLaunchpadsService_QueryLaunchpads(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	handleQuery(t, mux, "/launchpads/query", `{"name":"KSC LC 39A"}`)
+
+	ctx := context.Background()
+	results, _, err := client.Launchpads.QueryLaunchpads(ctx, testQuery)
+	if err != nil {
+		t.Fatalf("Launchpads.QueryLaunchpads returned error: %v", err)
+	}
+	if results.TotalDocs != 1 || len(results.Docs) != 1 || *results.Docs[0].Name != "KSC LC 39A" {
+		t.Errorf("Launchpads.QueryLaunchpads returned %+v", results)
+	}
+}
+
+func TestPayloadsService_QueryPayloads(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	handleQuery(t, mux, "/payloads/query", `{"name":"Tintin A & B"}`)
+
+	ctx := context.Background()
+	results, _, err := client.Payloads.QueryPayloads(ctx, testQuery)
+	if err != nil {
+		t.Fatalf("Payloads.QueryPayloads returned error: %v", err)
+	}
+	if results.TotalDocs != 1 || len(results.Docs) != 1 || *results.Docs[0].Name != "Tintin A & B" {
+		t.Errorf("Payloads.QueryPayloads returned %+v", results)
+	}
+}
+
+func TestShipsService_QueryShips(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	handleQuery(t, mux, "/ships/query", `{"name":"GO Ms Tree"}`)
+
+	ctx := context.Background()
+	results, _, err := client.Ships.QueryShips(ctx, testQuery)
+	if err != nil {
+		t.Fatalf("Ships.QueryShips returned error: %v", err)
+	}
+	if results.TotalDocs != 1 || len(results.Docs) != 1 || results.Docs[0].Name != "GO Ms Tree" {
+		t.Errorf("Ships.QueryShips returned %+v", results)
+	}
+}
+
+func TestStarlinkService_QueryStarlink(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	handleQuery(t, mux, "/starlink/query", `{"version":"v1.5"}`)
+
+	ctx := context.Background()
+	results, _, err := client.Starlink.QueryStarlink(ctx, testQuery)
+	if err != nil {
+		t.Fatalf("Starlink.QueryStarlink returned error: %v", err)
+	}
+	if results.TotalDocs != 1 || len(results.Docs) != 1 || *results.Docs[0].Version != "v1.5" {
+		t.Errorf("Starlink.QueryStarlink returned %+v", results)
+	}
+}
+
+func TestHistoryService_QueryHistory(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	handleQuery(t, mux, "/history/query", `{"title":"Falcon reaches Earth orbit"}`)
+
+	ctx := context.Background()
+	results, _, err := client.History.QueryHistory(ctx, testQuery)
+	if err != nil {
+		t.Fatalf("History.QueryHistory returned error: %v", err)
+	}
+	if results.TotalDocs != 1 || len(results.Docs) != 1 || *results.Docs[0].Title != "Falcon reaches Earth orbit" {
+		t.Errorf("History.QueryHistory returned %+v", results)
+	}
+}
+
+func TestRoadsterService_QueryRoadster(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/roadster/query", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Request method = %v, want %v", r.Method, "POST")
+		}
+		fmt.Fprint(w, `{"name":"Elon Musk's Tesla Roadster"}`)
+	})
+
+	ctx := context.Background()
+	q := query.New(nil, &query.Options{Select: query.Fields{"name"}})
+	roadster, _, err := client.Roadster.QueryRoadster(ctx, q)
+	if err != nil {
+		t.Fatalf("Roadster.QueryRoadster returned error: %v", err)
+	}
+	if roadster.Name != "Elon Musk's Tesla Roadster" {
+		t.Errorf("Roadster.QueryRoadster returned %+v", roadster)
+	}
+}
@@ -0,0 +1,109 @@
+package spacex
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket limiting the rate of requests sent by a
+// Client. It is safe for concurrent use, so one limiter can throttle all
+// services of a Client shared between many goroutines.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	waits     int64
+	waiting   int
+	totalWait time.Duration
+	lastWait  time.Duration
+}
+
+// RateLimiterStats reports how much a RateLimiter has delayed requests.
+type RateLimiterStats struct {
+	Waits     int64         // number of requests that had to wait
+	Waiting   int           // number of requests currently waiting
+	TotalWait time.Duration // cumulative time spent waiting
+	LastWait  time.Duration // delay imposed on the most recent waiting request
+}
+
+// NewRateLimiter returns a RateLimiter allowing requestsPerSecond requests on
+// average, with bursts of up to burst requests. It panics if
+// requestsPerSecond is not positive; WithRateLimit reports that as an error
+// instead.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if !(requestsPerSecond > 0) {
+		panic(fmt.Sprintf("spacex: NewRateLimiter: rate must be positive, got %v", requestsPerSecond))
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	// Reserve a token, possibly going into debt, and wait off the debt.
+	l.tokens--
+	if l.tokens >= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		l.tokens++
+		l.mu.Unlock()
+		return context.DeadlineExceeded
+	}
+	l.waits++
+	l.waiting++
+	l.lastWait = delay
+	l.mu.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		l.mu.Lock()
+		l.waiting--
+		l.totalWait += delay
+		l.mu.Unlock()
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.waiting--
+		l.tokens++ // give back the reservation
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Stats returns a snapshot of the limiter's wait statistics.
+func (l *RateLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return RateLimiterStats{
+		Waits:     l.waits,
+		Waiting:   l.waiting,
+		TotalWait: l.totalWait,
+		LastWait:  l.lastWait,
+	}
+}
@@ -0,0 +1,98 @@
+package spacex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	l := NewRateLimiter(100, 2)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatalf("Wait returned error: %v", err)
+		}
+	}
+	// Two requests fit in the burst; the other two need 10ms each.
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Errorf("4 requests took %v, want at least 15ms", elapsed)
+	}
+
+	stats := l.Stats()
+	if stats.Waits != 2 {
+		t.Errorf("Stats().Waits = %d, want 2", stats.Waits)
+	}
+	if stats.Waiting != 0 {
+		t.Errorf("Stats().Waiting = %d, want 0", stats.Waiting)
+	}
+}
+
+func TestRateLimiter_WaitCanceled(t *testing.T) {
+	l := NewRateLimiter(0.001, 1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait returned %v, want context.Canceled", err)
+	}
+}
+
+func TestNewRateLimiter_InvalidRate(t *testing.T) {
+	for _, rate := range []float64{0, -1, math.NaN()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewRateLimiter(%v, 1) did not panic", rate)
+				}
+			}()
+			NewRateLimiter(rate, 1)
+		}()
+	}
+}
+
+func TestDo_RateLimiterShared(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.RateLimiter = NewRateLimiter(200, 1)
+
+	mux.HandleFunc("/company", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"SpaceX"}`)
+	})
+	mux.HandleFunc("/roadster", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"Roadster"}`)
+	})
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, _, err := client.Company.GetCompanyInfo(ctx); err != nil {
+				t.Errorf("Company.GetCompanyInfo returned error: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if _, _, err := client.Roadster.GetRoadsterInfo(ctx); err != nil {
+				t.Errorf("Roadster.GetRoadsterInfo returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if stats := client.RateLimiter.Stats(); stats.Waits == 0 {
+		t.Errorf("Stats().Waits = 0, want concurrent requests to be throttled")
+	}
+}
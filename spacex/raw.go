@@ -0,0 +1,240 @@
+package spacex
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Document holds the JSON document a model was decoded from. Every
+// top-level model, such as Launch or Rocket, embeds it. Documents are only
+// kept when asked for, with Client.EnableRawDocuments or UnmarshalDocument,
+// as they cost a second pass over every response and a copy of its bytes.
+type Document struct {
+	// Extra holds the fields of the document that the model does not
+	// declare. They are included again when the model is marshalled.
+	Extra map[string]json.RawMessage `json:"-"`
+
+	raw json.RawMessage
+}
+
+// Raw returns the JSON document the model was decoded from, or nil if
+// documents were not kept.
+func (d *Document) Raw() json.RawMessage {
+	return d.raw
+}
+
+func (d *Document) document() *Document {
+	return d
+}
+
+// documentModel is implemented by pointers to the models embedding
+// Document.
+type documentModel interface {
+	document() *Document
+}
+
+// refDocument is implemented by pointers to references, returning the
+// populated document or nil.
+type refDocument interface {
+	doc() interface{}
+}
+
+var (
+	documentModelType = reflect.TypeFor[documentModel]()
+	refDocumentType   = reflect.TypeFor[refDocument]()
+)
+
+// UnmarshalDocument decodes data into v like json.Unmarshal, additionally
+// keeping the document and the undeclared fields of every model in v, as a
+// Client does with EnableRawDocuments.
+func UnmarshalDocument(data []byte, v interface{}) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	return keepDocuments(data, reflect.ValueOf(v), false)
+}
+
+// EnableRawDocuments makes the client keep the JSON document of every model
+// it decodes, available from its Raw method, and the fields the model does
+// not declare, in its Extra field.
+func (c *Client) EnableRawDocuments() {
+	c.rawDocuments = true
+}
+
+// decodeDocument decodes data, found at path in a response of endpoint,
+// into v, checking it for drift if strict decoding is enabled and keeping
+// the documents of the models in v if raw documents are.
+func (c *Client) decodeDocument(endpoint, path string, data []byte, v interface{}) error {
+	var err error
+	if c.driftReport != nil {
+		err = c.strictDecode(endpoint, path, data, v)
+	} else {
+		err = decodeBody(bytes.NewReader(data), v)
+	}
+	if err != nil || !c.rawDocuments || len(bytes.TrimSpace(data)) == 0 {
+		return err
+	}
+	return keepDocuments(data, reflect.ValueOf(v), false)
+}
+
+// keepDocuments stores data, the JSON value v was decoded from, in the
+// Documents of the models in v. embedded is set for the embedded structs of
+// a model, which share its Document.
+func keepDocuments(data []byte, v reflect.Value, embedded bool) error {
+	if !containsDocuments(v.Type()) {
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return keepDocuments(data, v.Elem(), false)
+	case reflect.Slice, reflect.Array:
+		if firstByte(data) != '[' {
+			return nil
+		}
+		var elems []json.RawMessage
+		if err := json.Unmarshal(data, &elems); err != nil {
+			return err
+		}
+		for i := 0; i < min(len(elems), v.Len()); i++ {
+			if err := keepDocuments(elems[i], v.Index(i), false); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		if !v.CanAddr() || !v.Addr().CanInterface() {
+			return nil
+		}
+		if ref, ok := v.Addr().Interface().(refDocument); ok {
+			if doc := ref.doc(); doc != nil {
+				return keepDocuments(data, reflect.ValueOf(doc), false)
+			}
+			return nil
+		}
+		if firstByte(data) != '{' {
+			return nil
+		}
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return err
+		}
+		if m, ok := v.Addr().Interface().(documentModel); ok && !embedded {
+			d := m.document()
+			d.raw = append(json.RawMessage(nil), data...)
+			d.Extra = nil
+			known := jsonFields(v.Type())
+			for k, val := range fields {
+				if _, ok := known[k]; ok {
+					continue
+				}
+				if d.Extra == nil {
+					d.Extra = make(map[string]json.RawMessage)
+				}
+				d.Extra[k] = val
+			}
+		}
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !containsDocuments(f.Type) {
+				continue
+			}
+			name, ok := jsonName(f)
+			switch {
+			case f.Anonymous && name == "":
+				if err := keepDocuments(data, v.Field(i), true); err != nil {
+					return err
+				}
+			case ok && f.IsExported():
+				if raw, ok := fields[name]; ok {
+					if err := keepDocuments(raw, v.Field(i), false); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// containsDocumentsCache maps types to whether they contain models.
+var containsDocumentsCache sync.Map
+
+// containsDocuments reports whether values of type t may contain models
+// embedding Document or populated references to them.
+func containsDocuments(t reflect.Type) bool {
+	if c, ok := containsDocumentsCache.Load(t); ok {
+		return c.(bool)
+	}
+	c := findDocuments(t, make(map[reflect.Type]bool))
+	containsDocumentsCache.Store(t, c)
+	return c
+}
+
+// findDocuments implements containsDocuments, visiting holding the types
+// being inspected to end recursion on cyclic types.
+func findDocuments(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	if c, ok := containsDocumentsCache.Load(t); ok {
+		return c.(bool)
+	}
+	if visiting[t] {
+		return false
+	}
+	visiting[t] = true
+
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return findDocuments(t.Elem(), visiting)
+	case reflect.Struct:
+		pt := reflect.PointerTo(t)
+		if pt.Implements(documentModelType) || pt.Implements(refDocumentType) {
+			return true
+		}
+		for i := 0; i < t.NumField(); i++ {
+			if findDocuments(t.Field(i).Type, visiting) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// marshalModel encodes v, a model converted to a type without methods, and
+// appends the fields in extra so that unknown fields survive a round trip.
+func marshalModel(v interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	known := jsonFields(reflect.TypeOf(v))
+	keys := make([]string, 0, len(extra))
+	for k := range extra {
+		if _, ok := known[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1]) // strip the closing brace
+	for i, k := range keys {
+		if i > 0 || len(data) > 2 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(extra[k])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,129 @@
+package spacex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestLaunchpad_Extra(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.EnableRawDocuments()
+
+	doc := `{"name":"VAFB SLC 4E","images":{"large":["https://i.imgur.com/7uXe1Kv.png"]},"details":"SpaceX's west coast launch site."}`
+	mux.HandleFunc("/launchpads/5e9e4502f509092b78566f87", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, doc)
+	})
+
+	launchpad, _, err := client.Launchpads.GetLaunchpad(context.Background(), "5e9e4502f509092b78566f87")
+	if err != nil {
+		t.Fatalf("Launchpads.GetLaunchpad returned error: %v", err)
+	}
+
+	if *launchpad.Name != "VAFB SLC 4E" {
+		t.Errorf("Name = %q, want %q", *launchpad.Name, "VAFB SLC 4E")
+	}
+	if got, want := string(launchpad.Extra["details"]), `"SpaceX's west coast launch site."`; got != want {
+		t.Errorf("Extra[details] = %s, want %s", got, want)
+	}
+	if _, ok := launchpad.Extra["name"]; ok {
+		t.Errorf("Extra contains declared field name")
+	}
+	if string(launchpad.Raw()) != doc {
+		t.Errorf("Raw() = %s, want %s", launchpad.Raw(), doc)
+	}
+}
+
+func TestModel_RoundTrip(t *testing.T) {
+	in := `{"serial":"C101","status":"retired","type":"Dragon 1.0","dragon":"","reuse_count":0,"water_landings":1,"land_landings":0,"last_update":null,"launches":[],"id":"5e9e2c5bf35918ed873b2664","new_field":{"nested":[1,2]}}`
+
+	capsule := new(Capsule)
+	if err := UnmarshalDocument([]byte(in), capsule); err != nil {
+		t.Fatalf("UnmarshalDocument returned error: %v", err)
+	}
+
+	out, err := json.Marshal(capsule)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+
+	var want, got map[string]interface{}
+	json.Unmarshal([]byte(in), &want)
+	json.Unmarshal(out, &got)
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("round trip produced %s, want %s", out, in)
+	}
+}
+
+func TestModel_MarshalWithoutExtra(t *testing.T) {
+	out, err := json.Marshal(Roadster{Name: "Roadster"})
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("json.Marshal produced invalid JSON %s: %v", out, err)
+	}
+	if got["name"] != "Roadster" {
+		t.Errorf("json.Marshal produced %s", out)
+	}
+	if _, ok := got["Extra"]; ok {
+		t.Errorf("json.Marshal included Extra field: %s", out)
+	}
+}
+
+func TestClient_RawDocumentsDisabled(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/launchpads/5e9e4502f509092b78566f87", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"VAFB SLC 4E","details":"SpaceX's west coast launch site."}`)
+	})
+
+	launchpad, _, err := client.Launchpads.GetLaunchpad(context.Background(), "5e9e4502f509092b78566f87")
+	if err != nil {
+		t.Fatalf("Launchpads.GetLaunchpad returned error: %v", err)
+	}
+	if launchpad.Extra != nil || launchpad.Raw() != nil {
+		t.Errorf("Launchpads.GetLaunchpad kept the document without EnableRawDocuments: %v, %s", launchpad.Extra, launchpad.Raw())
+	}
+}
+
+func TestClient_RawDocumentsNested(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.EnableRawDocuments()
+
+	mux.HandleFunc("/launches/query", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"docs":[{"name":"A","rocket":{"id":"r1","name":"Falcon 9","new_field":1}},{"name":"B","rocket":"r2"}],"totalDocs":2}`)
+	})
+	mux.HandleFunc("/ships", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"name":"GO Beyond","new_field":"x"}]`)
+	})
+
+	ctx := context.Background()
+	results, _, err := client.Launches.QueryLaunchesPopulated(ctx, nil)
+	if err != nil {
+		t.Fatalf("Launches.QueryLaunchesPopulated returned error: %v", err)
+	}
+	if got, want := string(results.Docs[1].Raw()), `{"name":"B","rocket":"r2"}`; got != want {
+		t.Errorf("Docs[1].Raw() = %s, want %s", got, want)
+	}
+	rocket := results.Docs[0].Rocket.Doc
+	if rocket == nil || string(rocket.Extra["new_field"]) != "1" {
+		t.Errorf("Docs[0].Rocket.Doc = %+v, want Extra new_field", rocket)
+	}
+
+	for ship, err := range client.Ships.All(ctx) {
+		if err != nil {
+			t.Fatalf("Ships.All returned error: %v", err)
+		}
+		if string(ship.Extra["new_field"]) != `"x"` {
+			t.Errorf("Ships.All yielded Extra %v, want new_field", ship.Extra)
+		}
+	}
+}
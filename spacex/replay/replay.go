@@ -0,0 +1,285 @@
+// Package replay provides an http.RoundTripper that records HTTP interactions
+// into cassette files and replays them, for deterministic tests that don't
+// depend on the live SpaceX API.
+//
+// Usage:
+//
+//	rec, err := replay.New("testdata/launches.json", replay.ModeReplay, nil)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer rec.Save()
+//	client := spacex.NewClient(&http.Client{Transport: rec})
+//
+// Record a cassette by running the test once with ModeRecord against the
+// live API.
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrUnrecorded is matched by errors.Is for requests that have no recorded
+// interaction in ModeReplay.
+var ErrUnrecorded = errors.New("replay: request not recorded")
+
+// Mode determines whether a Recorder replays or records interactions.
+type Mode int
+
+const (
+	// ModeReplay replays recorded interactions and fails requests that
+	// were not recorded with ErrUnrecorded. It never uses the network.
+	ModeReplay Mode = iota
+	// ModeRecord sends every request over the network and records the
+	// interaction, replacing the cassette on Save.
+	ModeRecord
+	// ModeReplayOrRecord replays recorded interactions and records
+	// requests that were not recorded yet.
+	ModeReplayOrRecord
+)
+
+func (m Mode) String() string {
+	switch m {
+	case ModeReplay:
+		return "replay"
+	case ModeRecord:
+		return "record"
+	case ModeReplayOrRecord:
+		return "replay-or-record"
+	}
+	return fmt.Sprintf("Mode(%d)", int(m))
+}
+
+// Cassette is the recorded interactions stored in a cassette file.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and the response it received.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request. Requests are matched on method, path, query
+// string and JSON body; the scheme and host are ignored, so cassettes work
+// against mirrors and test servers.
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// Recorder is an http.RoundTripper recording and replaying interactions. It
+// is safe for concurrent use.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	replayed map[*Interaction]bool
+	changed  bool
+}
+
+// New returns a Recorder using the cassette file at path in mode. Recorded
+// requests are sent with transport, or http.DefaultTransport if nil. The
+// cassette must exist in ModeReplay; in ModeRecord, it is ignored and
+// overwritten on Save.
+func New(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: transport,
+		replayed:  make(map[*Interaction]bool),
+	}
+	if mode == ModeRecord {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && mode == ModeReplayOrRecord {
+		return r, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &r.cassette); err != nil {
+		return nil, fmt.Errorf("replay: invalid cassette %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	recorded := Request{Method: req.Method, URL: req.URL.RequestURI(), Body: string(body)}
+
+	if r.mode != ModeRecord {
+		if in := r.lookup(recorded); in != nil {
+			return in.Response.httpResponse(req), nil
+		}
+		if r.mode == ModeReplay {
+			return nil, fmt.Errorf("%w: %s %s", ErrUnrecorded, req.Method, recorded.URL)
+		}
+	}
+
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	in := &Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header,
+			Body:       string(respBody),
+		},
+	}
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, in)
+	r.replayed[in] = true
+	r.changed = true
+	r.mu.Unlock()
+
+	return in.Response.httpResponse(req), nil
+}
+
+// lookup returns the interaction recorded for req. Identical requests
+// recorded several times are replayed in order, the last one repeating.
+func (r *Recorder) lookup(req Request) *Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var last *Interaction
+	for _, in := range r.cassette.Interactions {
+		if !in.Request.matches(req) {
+			continue
+		}
+		if !r.replayed[in] {
+			r.replayed[in] = true
+			return in
+		}
+		last = in
+	}
+	return last
+}
+
+// Save writes the cassette if interactions were recorded. The file is
+// written to a temporary file first and renamed into place.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.changed {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(&r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(r.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(append(data, '\n'))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), r.path); err != nil {
+		return err
+	}
+	r.changed = false
+	return nil
+}
+
+// Cassette returns a copy of the recorded interactions.
+func (r *Recorder) Cassette() Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return Cassette{Interactions: append([]*Interaction(nil), r.cassette.Interactions...)}
+}
+
+// matches reports whether r and other are the same request. JSON bodies
+// are compared by value, so the order of object keys doesn't matter.
+func (r Request) matches(other Request) bool {
+	if r.Method != other.Method || r.URL != other.URL {
+		return false
+	}
+	if r.Body == other.Body {
+		return true
+	}
+	var a, b interface{}
+	if json.Unmarshal([]byte(r.Body), &a) != nil || json.Unmarshal([]byte(other.Body), &b) != nil {
+		return false
+	}
+	ja, _ := json.Marshal(a)
+	jb, _ := json.Marshal(b)
+	return bytes.Equal(ja, jb)
+}
+
+// httpResponse returns the recorded response as the response to req.
+func (r Response) httpResponse(req *http.Request) *http.Response {
+	header := r.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewBufferString(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// readRequestBody reads and closes the body of req.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	return io.ReadAll(req.Body)
+}
@@ -0,0 +1,151 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/catdevman/go-spacex/spacex"
+)
+
+func newClient(t *testing.T, rec *Recorder, baseURL string) *spacex.Client {
+	t.Helper()
+	client := spacex.NewClient(&http.Client{Transport: rec})
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.BaseURL = u
+	return client
+}
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	calls := 0
+	mux.HandleFunc("/company", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprintf(w, `{"name":"SpaceX","employees":%d}`, 9500+calls)
+	})
+	mux.HandleFunc("/rockets/query", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"docs":[{"name":"Falcon 9"}],"totalDocs":1}`)
+	})
+
+	path := filepath.Join(t.TempDir(), "cassettes", "spacex.json")
+	rec, err := New(path, ModeRecord, nil)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	client := newClient(t, rec, server.URL+"/")
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Company.GetCompanyInfo(ctx); err != nil {
+			t.Fatalf("Company.GetCompanyInfo returned error: %v", err)
+		}
+	}
+	query := map[string]interface{}{"query": map[string]interface{}{"name": "Falcon 9", "active": true}}
+	if _, _, err := client.Rockets.QueryRockets(ctx, query); err != nil {
+		t.Fatalf("Rockets.QueryRockets returned error: %v", err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	server.Close()
+
+	rec, err = New(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	if got := len(rec.Cassette().Interactions); got != 3 {
+		t.Fatalf("cassette has %d interactions, want 3", got)
+	}
+	client = newClient(t, rec, "https://mirror.example.com/")
+
+	for i, want := range []int{9501, 9502, 9502} {
+		company, _, err := client.Company.GetCompanyInfo(ctx)
+		if err != nil {
+			t.Fatalf("Company.GetCompanyInfo returned error: %v", err)
+		}
+		if company.Employees != want {
+			t.Errorf("call %d: Employees = %d, want %d", i, company.Employees, want)
+		}
+	}
+
+	// Query bodies match regardless of key order.
+	reordered := map[string]interface{}{"query": map[string]interface{}{"active": true, "name": "Falcon 9"}}
+	results, _, err := client.Rockets.QueryRockets(ctx, reordered)
+	if err != nil {
+		t.Fatalf("Rockets.QueryRockets returned error: %v", err)
+	}
+	if len(results.Docs) != 1 || results.Docs[0].Name != "Falcon 9" {
+		t.Errorf("Rockets.QueryRockets returned %+v", results.Docs)
+	}
+
+	other := map[string]interface{}{"query": map[string]interface{}{"name": "Falcon 1"}}
+	if _, _, err := client.Rockets.QueryRockets(ctx, other); !errors.Is(err, ErrUnrecorded) {
+		t.Errorf("unrecorded query returned %v, want ErrUnrecorded", err)
+	}
+	if _, _, err := client.Roadster.GetRoadsterInfo(ctx); !errors.Is(err, ErrUnrecorded) {
+		t.Errorf("unrecorded request returned %v, want ErrUnrecorded", err)
+	}
+}
+
+func TestRecorder_ReplayOrRecord(t *testing.T) {
+	calls := 0
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"error":"Not Found"}`)),
+		}, nil
+	})
+
+	path := filepath.Join(t.TempDir(), "spacex.json")
+	rec, err := New(path, ModeReplayOrRecord, transport)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	client := newClient(t, rec, "https://api.spacexdata.com/v4/")
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Ships.GetShip(ctx, "unknown"); !errors.Is(err, spacex.ErrNotFound) {
+			t.Fatalf("Ships.GetShip returned %v, want ErrNotFound", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("transport saw %d calls, want 1", calls)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	rec, err = New(path, ModeReplay, transport)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	client = newClient(t, rec, "https://api.spacexdata.com/v4/")
+	if _, _, err := client.Ships.GetShip(ctx, "unknown"); !errors.Is(err, spacex.ErrNotFound) {
+		t.Errorf("Ships.GetShip returned %v, want replayed ErrNotFound", err)
+	}
+	if calls != 1 {
+		t.Errorf("transport saw %d calls, want 1", calls)
+	}
+}
+
+func TestNew_MissingCassette(t *testing.T) {
+	if _, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay, nil); err == nil {
+		t.Error("New returned no error for a missing cassette in ModeReplay")
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
@@ -0,0 +1,106 @@
+package spacex
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Header names of the metadata returned by the SpaceX API.
+const (
+	headerAPICache          = "spacex-api-cache"
+	headerAPIResponseTime   = "spacex-api-response-time"
+	headerRateLimitLimit    = "x-ratelimit-limit"
+	headerRateLimitRemain   = "x-ratelimit-remaining"
+	headerRateLimitReset    = "x-ratelimit-reset"
+	headerRequestID         = "x-request-id"
+	headerCloudflareRequest = "cf-ray"
+)
+
+// Response wraps the standard http.Response returned from the SpaceX API and
+// provides convenient access to its metadata.
+type Response struct {
+	*http.Response
+
+	// RateLimit holds the rate limit information from the response headers.
+	RateLimit RateLimit
+
+	// Latency is the time taken by the call, including any retries.
+	Latency time.Duration
+
+	// CacheHit reports whether the API served the response from its cache,
+	// as indicated by the spacex-api-cache header.
+	CacheHit bool
+
+	// FromCache reports whether the response was served from the client's
+	// own Cache without contacting the API.
+	FromCache bool
+
+	// NotModified reports whether the API answered a conditional request
+	// with 304 Not Modified, in which case the previously received body was
+	// served instead.
+	NotModified bool
+
+	// Shared reports whether the response was received by a concurrent
+	// identical call and shared with this one. See
+	// Client.EnableRequestCoalescing.
+	Shared bool
+
+	// RequestID identifies the request for debugging with the API operators.
+	RequestID string
+}
+
+// RateLimit represents the rate limit headers of an API response. Fields are
+// zero when the API did not send the corresponding header.
+type RateLimit struct {
+	Limit     int       // maximum number of requests per window
+	Remaining int       // requests remaining in the current window
+	Reset     time.Time // time at which the current window resets
+}
+
+// newResponse creates a new Response for the provided http.Response.
+func newResponse(r *http.Response, latency time.Duration) *Response {
+	response := &Response{
+		Response:  r,
+		Latency:   latency,
+		RateLimit: parseRateLimit(r.Header),
+		CacheHit:  strings.EqualFold(r.Header.Get(headerAPICache), "HIT"),
+		RequestID: r.Header.Get(headerRequestID),
+	}
+	if response.RequestID == "" {
+		response.RequestID = r.Header.Get(headerCloudflareRequest)
+	}
+	return response
+}
+
+// ServerResponseTime returns the processing time reported by the API in the
+// spacex-api-response-time header, and false if the header is missing.
+func (r *Response) ServerResponseTime() (time.Duration, bool) {
+	v := strings.TrimSuffix(r.Header.Get(headerAPIResponseTime), "ms")
+	ms, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(ms * float64(time.Millisecond)), true
+}
+
+// parseRateLimit parses the x-ratelimit-* headers. The reset header may be
+// given either as a Unix timestamp or as a number of seconds from now.
+func parseRateLimit(h http.Header) RateLimit {
+	var rate RateLimit
+	if v, err := strconv.Atoi(h.Get(headerRateLimitLimit)); err == nil {
+		rate.Limit = v
+	}
+	if v, err := strconv.Atoi(h.Get(headerRateLimitRemain)); err == nil {
+		rate.Remaining = v
+	}
+	if v, err := strconv.ParseInt(h.Get(headerRateLimitReset), 10, 64); err == nil {
+		if v > 1e9 {
+			rate.Reset = time.Unix(v, 0)
+		} else {
+			rate.Reset = time.Now().Add(time.Duration(v) * time.Second)
+		}
+	}
+	return rate
+}
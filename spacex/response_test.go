@@ -0,0 +1,71 @@
+package spacex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDo_Response(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	reset := time.Now().Add(time.Minute).Unix()
+	mux.HandleFunc("/rockets", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("spacex-api-cache", "HIT")
+		w.Header().Set("spacex-api-response-time", "12ms")
+		w.Header().Set("x-ratelimit-limit", "50")
+		w.Header().Set("x-ratelimit-remaining", "49")
+		w.Header().Set("x-ratelimit-reset", fmt.Sprint(reset))
+		w.Header().Set("x-request-id", "abc123")
+		fmt.Fprint(w, `[{"name":"Falcon 1"}]`)
+	})
+
+	ctx := context.Background()
+	rockets, resp, err := client.Rockets.ListAllRockets(ctx)
+	if err != nil {
+		t.Fatalf("Rockets.ListAllRockets returned error: %v", err)
+	}
+	if len(rockets) != 1 {
+		t.Fatalf("Rockets.ListAllRockets returned %d rockets, want 1", len(rockets))
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if !resp.CacheHit {
+		t.Errorf("CacheHit = false, want true")
+	}
+	if resp.RequestID != "abc123" {
+		t.Errorf("RequestID = %q, want %q", resp.RequestID, "abc123")
+	}
+	want := RateLimit{Limit: 50, Remaining: 49, Reset: time.Unix(reset, 0)}
+	if resp.RateLimit != want {
+		t.Errorf("RateLimit = %+v, want %+v", resp.RateLimit, want)
+	}
+	if d, ok := resp.ServerResponseTime(); !ok || d != 12*time.Millisecond {
+		t.Errorf("ServerResponseTime = %v, %v, want %v, true", d, ok, 12*time.Millisecond)
+	}
+	if resp.Latency <= 0 {
+		t.Errorf("Latency = %v, want positive", resp.Latency)
+	}
+}
+
+func TestDo_ResponseOnError(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/ships/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, resp, err := client.Ships.GetShip(context.Background(), "missing")
+	if err == nil {
+		t.Fatal("Ships.GetShip returned no error, want 404")
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Ships.GetShip returned response %+v, want status 404", resp)
+	}
+}
@@ -0,0 +1,168 @@
+package spacex
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how the client retries failed requests. Requests are
+// retried on connection errors and on responses with a retryable status code,
+// waiting an exponentially growing delay between attempts.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values below 2 disable retries.
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry. It doubles with every
+	// subsequent attempt, up to MaxDelay.
+	BaseDelay time.Duration
+
+	// MaxDelay caps every delay, including those requested by the server
+	// with a Retry-After header. Zero means defaultMaxRetryDelay.
+	MaxDelay time.Duration
+
+	// Jitter is the fraction, between 0 and 1, of each delay that is
+	// randomized to avoid synchronized retries from many clients.
+	Jitter float64
+
+	// RetryableStatuses lists the HTTP status codes that are retried.
+	RetryableStatuses []int
+
+	// RetryableMethods lists the HTTP methods that may be retried. The
+	// SpaceX API only uses POST for the read-only */query endpoints, so it
+	// is safe to include.
+	RetryableMethods []string
+}
+
+// defaultMaxRetryDelay caps retry delays when RetryPolicy.MaxDelay is zero.
+const defaultMaxRetryDelay = 30 * time.Second
+
+// DefaultRetryPolicy returns a RetryPolicy suitable for the public SpaceX API.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    defaultMaxRetryDelay,
+		Jitter:      0.2,
+		RetryableStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryableMethods: []string{"GET", "HEAD", "POST"},
+	}
+}
+
+// backoff returns the delay before retry number attempt (starting at 1).
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	d := math.Min(float64(p.BaseDelay)*math.Pow(2, float64(attempt-1)), float64(p.maxDelay()))
+	if p.Jitter > 0 {
+		d -= d * p.Jitter * rand.Float64()
+	}
+	return time.Duration(d)
+}
+
+// maxDelay returns the longest delay allowed between attempts.
+func (p *RetryPolicy) maxDelay() time.Duration {
+	if p.MaxDelay > 0 {
+		return p.MaxDelay
+	}
+	return defaultMaxRetryDelay
+}
+
+func (p *RetryPolicy) retryableMethod(method string) bool {
+	return slices.Contains(p.RetryableMethods, method)
+}
+
+func (p *RetryPolicy) retryableStatus(code int) bool {
+	return slices.Contains(p.RetryableStatuses, code)
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as
+// an HTTP date. It reports false if the header is absent or malformed.
+func parseRetryAfter(h string, now time.Time) (time.Duration, bool) {
+	if h == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(h); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(h); err == nil {
+		d := t.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// doWithRetry sends req, retrying according to the client's RetryPolicy or
+// the policy set for the call with WithRetryPolicy.
+// The returned response is the last one received; its body is left open.
+func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Response, error) {
+	p := c.RetryPolicy
+	if o := callOptionsFrom(ctx); o.retrySet {
+		p = o.retryPolicy
+	}
+	if p == nil || p.MaxAttempts < 2 || !p.retryableMethod(req.Method) {
+		return c.send(ctx, req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := c.send(ctx, req)
+		if attempt >= p.MaxAttempts || ctx.Err() != nil {
+			return resp, err
+		}
+
+		delay := p.backoff(attempt)
+		switch {
+		case err != nil:
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) ||
+				errors.Is(err, ErrCircuitOpen) {
+				return resp, err
+			}
+		case p.retryableStatus(resp.StatusCode):
+			if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				delay = min(d, p.maxDelay())
+			}
+		default:
+			return resp, nil
+		}
+
+		// Don't start a wait that would outlive the caller's deadline.
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return resp, err
+		}
+
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
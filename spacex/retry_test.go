@@ -0,0 +1,153 @@
+package spacex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	p := DefaultRetryPolicy()
+	p.BaseDelay = time.Millisecond
+	p.MaxDelay = 5 * time.Millisecond
+	return p
+}
+
+func TestDo_RetriesServerErrors(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+
+	attempts := 0
+	mux.HandleFunc("/launches/query", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := io.ReadAll(r.Body)
+		if got, want := string(body), "{\"query\":{\"upcoming\":true}}\n"; got != want {
+			t.Errorf("attempt %d: request body = %q, want %q", attempts, got, want)
+		}
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"docs":[{"name":"FalconSat"}],"totalDocs":1}`)
+	})
+
+	ctx := context.Background()
+	q := map[string]interface{}{"query": map[string]interface{}{"upcoming": true}}
+	results, _, err := client.Launches.QueryLaunches(ctx, q)
+	if err != nil {
+		t.Fatalf("Launches.QueryLaunches returned error: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("server saw %d attempts, want 3", attempts)
+	}
+	if len(results.Docs) != 1 || results.Docs[0].Name != "FalconSat" {
+		t.Errorf("Launches.QueryLaunches returned %+v", results.Docs)
+	}
+}
+
+func TestDo_RetryGivesUp(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+
+	attempts := 0
+	mux.HandleFunc("/company", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	_, _, err := client.Company.GetCompanyInfo(context.Background())
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Company.GetCompanyInfo returned %v, want ErrRateLimited", err)
+	}
+	if attempts != client.RetryPolicy.MaxAttempts {
+		t.Errorf("server saw %d attempts, want %d", attempts, client.RetryPolicy.MaxAttempts)
+	}
+}
+
+func TestDo_NoRetryOnClientError(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+
+	attempts := 0
+	mux.HandleFunc("/cores/missing", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, _, err := client.Cores.GetCore(context.Background(), "missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Cores.GetCore returned %v, want ErrNotFound", err)
+	}
+	if attempts != 1 {
+		t.Errorf("server saw %d attempts, want 1", attempts)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		header string
+		want   time.Duration
+		ok     bool
+	}{
+		{"", 0, false},
+		{"5", 5 * time.Second, true},
+		{"Wed, 01 Jan 2020 00:00:10 GMT", 10 * time.Second, true},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.header, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", tt.header, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestDo_RetryAfterCappedByMaxDelay(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+
+	attempts := 0
+	mux.HandleFunc("/company", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"name":"SpaceX"}`)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, _, err := client.Company.GetCompanyInfo(ctx); err != nil {
+		t.Fatalf("Company.GetCompanyInfo returned error: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("server saw %d attempts, want 2", attempts)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: time.Second}
+	for _, attempt := range []int{1, 10, 100, 2000} {
+		if d := p.backoff(attempt); d <= 0 || d > defaultMaxRetryDelay {
+			t.Errorf("backoff(%d) = %v, want within (0, %v]", attempt, d, defaultMaxRetryDelay)
+		}
+	}
+
+	p.MaxDelay = 3 * time.Second
+	if d := p.backoff(5); d != p.MaxDelay {
+		t.Errorf("backoff(5) = %v, want %v", d, p.MaxDelay)
+	}
+}
@@ -1,6 +1,8 @@
 package spacex
 
-import "context"
+import (
+	"context"
+)
 
 // RoadsterService handles communication with the roadster related
 // methods of the SpaceX API.
@@ -36,21 +38,48 @@ type Roadster struct {
 	Wikipedia       string   `json:"wikipedia"`
 	Video           string   `json:"video"`
 	Details         string   `json:"details"`
+
+	Document
+}
+
+// MarshalJSON implements json.Marshaler, including the fields in Extra.
+func (r Roadster) MarshalJSON() ([]byte, error) {
+	type roadster Roadster
+	return marshalModel(roadster(r), r.Extra)
 }
 
 // GetRoadsterInfo retrieves roadster information.
-func (s *RoadsterService) GetRoadsterInfo(ctx context.Context) (*Roadster, error) {
+func (s *RoadsterService) GetRoadsterInfo(ctx context.Context, opts ...CallOption) (*Roadster, *Response, error) {
 	u := "roadster"
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	roadster := new(Roadster)
+	resp, err := s.client.do(ctx, req, roadster)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return roadster, resp, nil
+}
+
+// QueryRoadster queries for roadster information. There is a single roadster,
+// so the API answers with the roadster itself rather than paginated results;
+// the query options can still select fields.
+func (s *RoadsterService) QueryRoadster(ctx context.Context, query map[string]interface{}, opts ...CallOption) (*Roadster, *Response, error) {
+	u := "roadster/query"
+	req, err := s.client.newRequest(ctx, "POST", u, query, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	roadster := new(Roadster)
-	_, err = s.client.do(ctx, req, roadster)
+	resp, err := s.client.do(ctx, req, roadster)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return roadster, nil
+	return roadster, resp, nil
 }
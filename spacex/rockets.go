@@ -3,6 +3,7 @@ package spacex
 import (
 	"context"
 	"fmt"
+	"iter"
 )
 
 // RocketsService handles communication with the rocket related
@@ -35,6 +36,14 @@ type Rocket struct {
 	Wikipedia      string          `json:"wikipedia"`
 	Description    string          `json:"description"`
 	ID             string          `json:"id"`
+
+	Document
+}
+
+// MarshalJSON implements json.Marshaler, including the fields in Extra.
+func (r Rocket) MarshalJSON() ([]byte, error) {
+	type rocket Rocket
+	return marshalModel(rocket(r), r.Extra)
 }
 
 // Dimension represents height and diameter measurements.
@@ -111,66 +120,55 @@ type LandingLegs struct {
 }
 
 // RocketQueryResults represents the result of a rocket query.
-type RocketQueryResults struct {
-	Docs          []*Rocket `json:"docs"`
-	TotalDocs     int       `json:"totalDocs"`
-	Limit         int       `json:"limit"`
-	TotalPages    int       `json:"totalPages"`
-	Page          int       `json:"page"`
-	PagingCounter int       `json:"pagingCounter"`
-	HasPrevPage   bool      `json:"hasPrevPage"`
-	HasNextPage   bool      `json:"hasNextPage"`
-	PrevPage      *int      `json:"prevPage"`
-	NextPage      *int      `json:"nextPage"`
-}
+type RocketQueryResults = Page[*Rocket]
 
 // ListAllRockets lists all rockets.
-func (s *RocketsService) ListAllRockets(ctx context.Context) ([]*Rocket, error) {
+func (s *RocketsService) ListAllRockets(ctx context.Context, opts ...CallOption) ([]*Rocket, *Response, error) {
 	u := "rockets"
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var rockets []*Rocket
-	_, err = s.client.do(ctx, req, &rockets)
+	resp, err := s.client.do(ctx, req, &rockets)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return rockets, nil
+	return rockets, resp, nil
+}
+
+// All iterates over all rockets, decoding them one at a time.
+func (s *RocketsService) All(ctx context.Context, opts ...CallOption) iter.Seq2[*Rocket, error] {
+	return streamList[Rocket](ctx, s.client, "rockets", opts...)
 }
 
 // GetRocket retrieves a specific rocket.
-func (s *RocketsService) GetRocket(ctx context.Context, id string) (*Rocket, error) {
+func (s *RocketsService) GetRocket(ctx context.Context, id string, opts ...CallOption) (*Rocket, *Response, error) {
 	u := fmt.Sprintf("rockets/%s", id)
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	rocket := new(Rocket)
-	_, err = s.client.do(ctx, req, rocket)
+	resp, err := s.client.do(ctx, req, rocket)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return rocket, nil
+	return rocket, resp, nil
 }
 
-// QueryRockets queries for rockets.
-func (s *RocketsService) QueryRockets(ctx context.Context, query map[string]interface{}) (*RocketQueryResults, error) {
-	u := "rockets/query"
-	req, err := s.client.newRequest(ctx, "POST", u, query)
-	if err != nil {
-		return nil, err
-	}
-
-	results := new(RocketQueryResults)
-	_, err = s.client.do(ctx, req, results)
-	if err != nil {
-		return nil, err
-	}
+// GetMany retrieves the rockets with the given IDs, in order. IDs that don't
+// exist have nil entries and are reported in a *MissingIDsError.
+func (s *RocketsService) GetMany(ctx context.Context, ids []string, opts ...CallOption) ([]*Rocket, *Response, error) {
+	return getMany(ctx, s.client, "rockets", ids, func(r *Rocket) string { return r.ID }, opts)
+}
 
-	return results, nil
+// QueryRockets queries for rockets. The query can be built with the query
+// package.
+func (s *RocketsService) QueryRockets(ctx context.Context, query map[string]interface{}, opts ...CallOption) (*RocketQueryResults, *Response, error) {
+	return queryPage[*Rocket](ctx, s.client, "rockets", query, opts)
 }
@@ -3,6 +3,7 @@ package spacex
 import (
 	"context"
 	"fmt"
+	"iter"
 )
 
 // ShipsService handles communication with the ship related
@@ -36,38 +37,67 @@ type Ship struct {
 	Link          *string  `json:"link"`
 	Image         *string  `json:"image"`
 	Launches      []string `json:"launches"`
+	ID            string   `json:"id"`
+
+	Document
+}
+
+// MarshalJSON implements json.Marshaler, including the fields in Extra.
+func (s Ship) MarshalJSON() ([]byte, error) {
+	type ship Ship
+	return marshalModel(ship(s), s.Extra)
 }
 
+// ShipQueryResults represents the result of a ship query.
+type ShipQueryResults = Page[*Ship]
+
 // ListAllShips lists all ships.
-func (s *ShipsService) ListAllShips(ctx context.Context) ([]*Ship, error) {
+func (s *ShipsService) ListAllShips(ctx context.Context, opts ...CallOption) ([]*Ship, *Response, error) {
 	u := "ships"
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var ships []*Ship
-	_, err = s.client.do(ctx, req, &ships)
+	resp, err := s.client.do(ctx, req, &ships)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return ships, nil
+	return ships, resp, nil
+}
+
+// All iterates over all ships, decoding them one at a time.
+func (s *ShipsService) All(ctx context.Context, opts ...CallOption) iter.Seq2[*Ship, error] {
+	return streamList[Ship](ctx, s.client, "ships", opts...)
 }
 
 // GetShip retrieves a specific ship.
-func (s *ShipsService) GetShip(ctx context.Context, id string) (*Ship, error) {
+func (s *ShipsService) GetShip(ctx context.Context, id string, opts ...CallOption) (*Ship, *Response, error) {
 	u := fmt.Sprintf("ships/%s", id)
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	ship := new(Ship)
-	_, err = s.client.do(ctx, req, ship)
+	resp, err := s.client.do(ctx, req, ship)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return ship, nil
+	return ship, resp, nil
+}
+
+// GetMany retrieves the ships with the given IDs, in order. IDs that don't
+// exist have nil entries and are reported in a *MissingIDsError.
+func (s *ShipsService) GetMany(ctx context.Context, ids []string, opts ...CallOption) ([]*Ship, *Response, error) {
+	return getMany(ctx, s.client, "ships", ids, func(ship *Ship) string { return ship.ID }, opts)
+}
+
+// QueryShips queries for ships. The query can be built with the query
+// package.
+func (s *ShipsService) QueryShips(ctx context.Context, query map[string]interface{}, opts ...CallOption) (*ShipQueryResults, *Response, error) {
+	return queryPage[*Ship](ctx, s.client, "ships", query, opts)
 }
@@ -0,0 +1,83 @@
+package spacex
+
+import (
+	"context"
+	"sync"
+)
+
+// flightGroup coalesces concurrent identical requests so that only one of
+// them reaches the network.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// flightCall is an in-flight or completed request shared by its callers.
+type flightCall struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int // callers waiting for the result, guarded by flightGroup.mu
+	resp    *Response
+	data    []byte
+	err     error
+}
+
+// do runs fn for key unless an identical call is already in flight, in which
+// case it waits for that call's result. shared reports whether the result
+// came from another caller's call. The call runs under a context detached
+// from the cancellation and deadline of the caller that started it, so that
+// it completes for the others. Every caller gives up when its own ctx is
+// done, and the call is canceled once all of them have.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) (*Response, []byte, error)) (resp *Response, data []byte, shared bool, err error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	call, shared := g.calls[key]
+	if !shared {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &flightCall{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = call
+		go func() {
+			call.resp, call.data, call.err = fn(callCtx)
+			cancel()
+			g.mu.Lock()
+			g.forget(key, call)
+			g.mu.Unlock()
+			close(call.done)
+		}()
+	}
+	call.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.resp, call.data, shared, call.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			call.cancel()
+			g.forget(key, call)
+		}
+		g.mu.Unlock()
+		return nil, nil, false, ctx.Err()
+	}
+}
+
+// forget removes call from the in-flight calls, so later callers start a
+// new one. g.mu must be held.
+func (g *flightGroup) forget(key string, call *flightCall) {
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+}
+
+// EnableRequestCoalescing makes concurrent identical GET requests and
+// queries share a single round trip. Every caller decodes its own copy of
+// the shared response body, and followers' responses have Shared set.
+func (c *Client) EnableRequestCoalescing() {
+	if c.flights == nil {
+		c.flights = new(flightGroup)
+	}
+}
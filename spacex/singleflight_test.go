@@ -0,0 +1,187 @@
+package spacex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_RequestCoalescing(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.EnableRequestCoalescing()
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	mux.HandleFunc("/rockets/5e9d0d95eda69955f709d1eb", func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		fmt.Fprint(w, `{"name":"Falcon 1"}`)
+	})
+
+	const n = 10
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	var shared atomic.Int32
+	rockets := make([]*Rocket, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			rocket, resp, err := client.Rockets.GetRocket(ctx, "5e9d0d95eda69955f709d1eb")
+			if err != nil {
+				t.Errorf("Rockets.GetRocket returned error: %v", err)
+				return
+			}
+			if resp.Shared {
+				shared.Add(1)
+			}
+			rockets[i] = rocket
+		}(i)
+	}
+
+	waitForWaiters(t, client.flights, n)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server saw %d calls, want 1", got)
+	}
+	if got := shared.Load(); got != n-1 {
+		t.Errorf("%d responses marked Shared, want %d", got, n-1)
+	}
+	for i, r := range rockets {
+		if r == nil || r.Name != "Falcon 1" {
+			t.Errorf("caller %d got %+v", i, r)
+		}
+	}
+	if rockets[0] == rockets[1] {
+		t.Errorf("callers share the same decoded value, want independent copies")
+	}
+}
+
+func TestClient_RequestCoalescingSequential(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.EnableRequestCoalescing()
+
+	calls := 0
+	mux.HandleFunc("/company", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"name":"SpaceX"}`)
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Company.GetCompanyInfo(ctx); err != nil {
+			t.Fatalf("Company.GetCompanyInfo returned error: %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("server saw %d calls, want 2", calls)
+	}
+}
+
+// waitForWaiters waits until n callers are waiting on the calls of g.
+func waitForWaiters(t *testing.T, g *flightGroup, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		g.mu.Lock()
+		waiters := 0
+		for _, call := range g.calls {
+			waiters += call.waiters
+		}
+		g.mu.Unlock()
+		if waiters >= n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d callers waiting, want %d", waiters, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestClient_RequestCoalescingFirstCallerCanceled(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.EnableRequestCoalescing()
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	mux.HandleFunc("/company", func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		fmt.Fprint(w, `{"name":"SpaceX"}`)
+	})
+
+	first, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, _, err := client.Company.GetCompanyInfo(first)
+		firstErr <- err
+	}()
+	waitForWaiters(t, client.flights, 1)
+
+	type result struct {
+		resp *Response
+		err  error
+	}
+	second := make(chan result, 1)
+	go func() {
+		_, resp, err := client.Company.GetCompanyInfo(context.Background())
+		second <- result{resp, err}
+	}()
+	waitForWaiters(t, client.flights, 2)
+
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("first caller got %v, want context.Canceled", err)
+	}
+	close(release)
+
+	r := <-second
+	if r.err != nil {
+		t.Fatalf("second caller got error: %v", r.err)
+	}
+	if !r.resp.Shared {
+		t.Errorf("second caller's response not marked Shared")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server saw %d calls, want 1", got)
+	}
+}
+
+func TestClient_RequestCoalescingAllCanceled(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.EnableRequestCoalescing()
+
+	canceled := make(chan struct{})
+	mux.HandleFunc("/company", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		close(canceled)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		client.Company.GetCompanyInfo(ctx)
+	}()
+	waitForWaiters(t, client.flights, 1)
+	cancel()
+	<-done
+
+	select {
+	case <-canceled:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("shared call not canceled after its only caller gave up")
+	}
+}
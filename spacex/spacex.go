@@ -8,7 +8,9 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"path"
 	"strings"
+	"time"
 )
 
 const (
@@ -17,11 +19,70 @@ const (
 )
 
 // Client for the SpaceX API.
+//
+// The All methods of the services decode lists one element at a time as
+// they arrive, rather than loading the whole list into memory. The response
+// body is still buffered when caching, conditional requests or request
+// coalescing are enabled, as they keep or share it.
 type Client struct {
 	client    *http.Client
 	BaseURL   *url.URL
 	UserAgent string
 
+	// APIVersions overrides the API version used by individual services,
+	// keyed by the service's path such as "capsules". Services not listed
+	// use the version in BaseURL. The launches service can't use v5; its v5
+	// endpoints are served by the V5 methods of LaunchesService.
+	APIVersions map[string]string
+
+	// RetryPolicy controls retries of failed requests. A nil policy makes
+	// exactly one attempt per request.
+	RetryPolicy *RetryPolicy
+
+	// RateLimiter, if set, throttles every request sent by the client,
+	// including retries.
+	RateLimiter *RateLimiter
+
+	// Cache, if set, stores the responses of GET requests and queries.
+	// CachePolicy determines how long they are kept; a nil policy uses
+	// DefaultCachePolicy.
+	Cache       Cache
+	CachePolicy *CachePolicy
+
+	// CircuitBreaker, if set, fails requests fast with ErrCircuitOpen
+	// while their endpoint family is failing.
+	CircuitBreaker *CircuitBreaker
+
+	// validatorStore remembers ETag and Last-Modified validators per URL
+	// when conditional requests are enabled.
+	validatorStore *lru[*validatorEntry]
+
+	middleware []Middleware
+
+	// driftReport collects schema drift when strict decoding is enabled.
+	driftReport *DriftReport
+
+	// rawDocuments keeps the documents of decoded models when enabled.
+	rawDocuments bool
+
+	// mirrors holds the base URLs to fail over between, if configured.
+	mirrors *mirrorSet
+
+	// Tracer, if set, traces every API call.
+	Tracer Tracer
+
+	// Metrics, if set, records the count, latency and outcome of every API
+	// call by endpoint.
+	Metrics *Metrics
+
+	// flights coalesces identical requests when request coalescing is
+	// enabled.
+	flights *flightGroup
+
+	// getManyBatchSize overrides defaultGetManyBatchSize, if positive. It
+	// is set with WithGetManyBatchSize.
+	getManyBatchSize int
+
 	Capsules   *CapsulesService
 	Company    *CompanyService
 	Cores      *CoresService
@@ -38,19 +99,22 @@ type Client struct {
 	Starlink   *StarlinkService
 }
 
-// NewClient returns a new SpaceX API client.
+// NewClient returns a new SpaceX API client. If a nil httpClient is
+// provided, http.DefaultClient will be used. Use NewClientWithOptions for
+// further configuration.
 func NewClient(httpClient *http.Client) *Client {
 	if httpClient == nil {
 		httpClient = http.DefaultClient
 	}
+	return newClient(httpClient)
+}
 
+func newClient(httpClient *http.Client) *Client {
 	c := &Client{
 		client:    httpClient,
 		UserAgent: userAgent,
 	}
-	if c.BaseURL == nil {
-		c.BaseURL, _ = url.Parse(defaultBaseURL)
-	}
+	c.BaseURL, _ = url.Parse(defaultBaseURL)
 
 	c.Capsules = &CapsulesService{client: c}
 	c.Company = &CompanyService{client: c}
@@ -70,12 +134,42 @@ func NewClient(httpClient *http.Client) *Client {
 	return c
 }
 
-func (c *Client) newRequest(ctx context.Context, method, urlStr string, body interface{}) (*http.Request, error) {
+// newRequest creates an API request bound to ctx. A relative URL can be
+// provided in urlStr, in which case it is resolved relative to the BaseURL of
+// the Client, using the API version configured for the service in
+// APIVersions. The call options are carried in the request context. It
+// fails if the service's methods can't decode responses of that version.
+func (c *Client) newRequest(ctx context.Context, method, urlStr string, body interface{}, opts ...CallOption) (*http.Request, error) {
+	service, _, _ := strings.Cut(urlStr, "/")
+	version := c.APIVersions[service]
+	effective := version
+	if effective == "" {
+		effective = path.Base(strings.TrimSuffix(c.BaseURL.Path, "/"))
+	}
+	if err := checkServiceVersion(service, effective); err != nil {
+		return nil, err
+	}
+	return c.newVersionedRequest(ctx, version, method, urlStr, body, opts...)
+}
+
+// newVersionedRequest creates an API request against the given API version,
+// or the version in BaseURL if version is empty.
+func (c *Client) newVersionedRequest(ctx context.Context, version, method, urlStr string, body interface{}, opts ...CallOption) (*http.Request, error) {
 	if !strings.HasSuffix(c.BaseURL.Path, "/") {
 		return nil, fmt.Errorf("baseURL must have a trailing slash, but %q does not", c.BaseURL)
 	}
 
-	u, err := c.BaseURL.Parse(urlStr)
+	base := c.BaseURL
+	if version != "" {
+		if !apiVersionRE.MatchString(version) {
+			return nil, fmt.Errorf("invalid API version %q", version)
+		}
+		b := *c.BaseURL
+		b.Path = withVersion(b.Path, version)
+		base = &b
+	}
+
+	u, err := base.Parse(urlStr)
 	if err != nil {
 		return nil, err
 	}
@@ -89,7 +183,7 @@ func (c *Client) newRequest(ctx context.Context, method, urlStr string, body int
 		}
 	}
 
-	req, err := http.NewRequest(method, u.String(), buf)
+	req, err := http.NewRequestWithContext(withCallOptions(ctx, opts), method, u.String(), buf)
 	if err != nil {
 		return nil, err
 	}
@@ -98,48 +192,240 @@ func (c *Client) newRequest(ctx context.Context, method, urlStr string, body int
 		req.Header.Set("Content-Type", "application/json")
 	}
 	req.Header.Set("User-Agent", c.UserAgent)
+	for k, v := range callOptionsFrom(req.Context()).header {
+		req.Header[k] = v
+	}
 
 	return req, nil
 }
 
-func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) (*http.Response, error) {
+// do sends an API request and returns the API response. The API response is
+// JSON decoded and stored in the value pointed to by v, or returned as an
+// error if an API error has occurred. If v implements the io.Writer
+// interface, the raw response body will be written to v, without attempting
+// to first decode it. The call options of req apply.
+func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) (resp *Response, err error) {
+	ctx, cancel := callContext(ctx, req)
+	defer cancel()
+	ctx, call := c.startCall(ctx, req)
+	defer func() { call.end(resp, err) }()
+
+	resp, body, err := c.bareDo(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+	body = call.countBody(body)
+	defer body.Close()
+
+	if _, ok := v.(io.Writer); (c.driftReport != nil || c.rawDocuments) && v != nil && !ok {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return resp, err
+		}
+		return resp, c.decodeDocument(endpointPattern(c.endpoint(req)), "", data, v)
+	}
+
+	if err := decodeBody(body, v); err != nil {
+		return resp, err
+	}
+	// Drain the remainder so the body is recorded for caching and the
+	// connection can be reused.
+	_, err = io.Copy(io.Discard, body)
+	return resp, err
+}
+
+// bareDo sends an API request and returns the API response along with its
+// body, which the caller must close. The body is served from the Cache or
+// from a revalidated earlier response when possible, and recorded into them
+// once read to the end. Calls with the NoCache option skip the Cache and
+// revalidation, but still record the fresh response.
+func (c *Client) bareDo(ctx context.Context, req *http.Request) (*Response, io.ReadCloser, error) {
 	req = req.WithContext(ctx)
+	noCache := callOptionsFrom(ctx).noCache
+
+	key, ttl := c.cacheKey(req)
+	if key != "" && !noCache {
+		if data, ok := c.Cache.Get(key); ok {
+			resp := newCachedResponse(req, data)
+			return resp, resp.Body, nil
+		}
+	}
+
+	if c.flights != nil && !noCache {
+		if flightKey := c.requestKey(req); flightKey != "" {
+			resp, data, shared, err := c.flights.do(ctx, flightKey, func(ctx context.Context) (*Response, []byte, error) {
+				resp, body, err := c.fetch(ctx, req.WithContext(ctx), key, ttl)
+				if err != nil {
+					return resp, nil, err
+				}
+				defer body.Close()
+				data, err := io.ReadAll(body)
+				return resp, data, err
+			})
+			if shared && resp != nil {
+				r := *resp
+				r.Shared = true
+				resp = &r
+			}
+			if err != nil {
+				return resp, nil, err
+			}
+			return resp, io.NopCloser(bytes.NewReader(data)), nil
+		}
+	}
+
+	return c.fetch(ctx, req, key, ttl)
+}
 
-	resp, err := c.client.Do(req)
+// fetch sends req over the network, revalidating an earlier response if
+// possible. When key is set, the body is stored in the Cache with ttl once
+// read to the end.
+func (c *Client) fetch(ctx context.Context, req *http.Request, key string, ttl time.Duration) (*Response, io.ReadCloser, error) {
+	entry, revalidate := c.validators(req)
+	revalidate = revalidate && !callOptionsFrom(ctx).noCache
+	if revalidate {
+		setConditionalHeaders(req, entry)
+	}
+
+	start := time.Now()
+	httpResp, err := c.doWithRetry(ctx, req)
 	if err != nil {
 		// If we got an error, and the context has been canceled,
 		// the context's error is probably more useful.
 		select {
 		case <-ctx.Done():
-			return nil, ctx.Err()
+			return nil, nil, ctx.Err()
 		default:
 		}
 
-		return nil, err
+		return nil, nil, err
 	}
-	defer resp.Body.Close()
+	resp := newResponse(httpResp, time.Since(start))
 
-	if c := resp.StatusCode; 200 <= c && c <= 299 {
-		if v != nil {
-			if w, ok := v.(io.Writer); ok {
-				io.Copy(w, resp.Body)
-			} else {
-				decErr := json.NewDecoder(resp.Body).Decode(v)
-				if decErr == io.EOF {
-					decErr = nil // ignore EOF errors caused by empty response body
-				}
-				if decErr != nil {
-					err = decErr
-				}
-			}
+	if revalidate && httpResp.StatusCode == http.StatusNotModified {
+		httpResp.Body.Close()
+		resp.NotModified = true
+		if key != "" {
+			c.Cache.Set(key, entry.Body, ttl)
 		}
-		return resp, err
+		return resp, io.NopCloser(bytes.NewReader(entry.Body)), nil
+	}
+
+	if err := checkResponse(httpResp); err != nil {
+		httpResp.Body.Close()
+		return resp, nil, err
+	}
+
+	if key != "" || (c.validatorStore != nil && req.Method == "GET") {
+		return resp, &recordingBody{ReadCloser: httpResp.Body, onEOF: func(data []byte) {
+			if key != "" {
+				c.Cache.Set(key, data, ttl)
+			}
+			c.rememberValidators(req, httpResp, data)
+		}}, nil
+	}
+
+	return resp, httpResp.Body, nil
+}
+
+// recordingBody is a response body that keeps a copy of the data read and
+// hands it to onEOF once the body has been read completely.
+type recordingBody struct {
+	io.ReadCloser
+	buf   bytes.Buffer
+	onEOF func(data []byte)
+	done  bool
+}
+
+func (r *recordingBody) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.buf.Write(p[:n])
+	if err == io.EOF && !r.done {
+		r.done = true
+		r.onEOF(r.buf.Bytes())
+	}
+	return n, err
+}
+
+// decodeBody decodes a response body into v. If v implements the io.Writer
+// interface, the raw body is copied to it instead.
+func decodeBody(r io.Reader, v interface{}) error {
+	if v == nil {
+		return nil
+	}
+	if w, ok := v.(io.Writer); ok {
+		_, err := io.Copy(w, r)
+		return err
+	}
+
+	err := json.NewDecoder(r).Decode(v)
+	if err == io.EOF {
+		err = nil // ignore EOF errors caused by empty response body
+	}
+	return err
+}
+
+// EnableConditionalRequests makes the client remember the ETag and
+// Last-Modified validators of GET responses and revalidate later requests to
+// the same URL with If-None-Match and If-Modified-Since. When the API
+// answers 304 Not Modified, the previously received body is decoded again
+// and the Response has NotModified set.
+func (c *Client) EnableConditionalRequests() {
+	if c.validatorStore == nil {
+		c.validatorStore = newLRU[*validatorEntry](maxValidators)
+	}
+}
+
+// endpoint returns the path of req relative to the versioned base URL, such
+// as "launches/next".
+func (c *Client) endpoint(req *http.Request) string {
+	p := strings.TrimPrefix(req.URL.Path, withoutVersion(c.BaseURL.Path))
+	if version, rest, ok := strings.Cut(p, "/"); ok && apiVersionRE.MatchString(version) {
+		return rest
 	}
+	return p
+}
 
-	bodyBytes, err := io.ReadAll(resp.Body)
+// endpointPattern returns endpoint with resource IDs replaced by "{id}",
+// such as "starlink/{id}", for grouping requests by endpoint.
+func endpointPattern(endpoint string) string {
+	service, rest, ok := strings.Cut(endpoint, "/")
+	if !ok {
+		return endpoint
+	}
+	switch rest {
+	case "query", "latest", "next", "past", "upcoming":
+		return endpoint
+	}
+	return service + "/{id}"
+}
+
+// send performs a single HTTP round trip, failing over between mirrors if
+// configured, and checking the circuit breaker first.
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if info := callInfoFrom(ctx); info != nil {
+		info.attempts.Add(1)
+	}
+	if c.CircuitBreaker == nil {
+		return c.sendFailover(ctx, req)
+	}
+
+	family, err := c.CircuitBreaker.allow(c.endpoint(req))
 	if err != nil {
-		return resp, fmt.Errorf("failed to read error response body: %w", err)
+		return nil, err
 	}
+	resp, err := c.sendFailover(ctx, req)
+	c.CircuitBreaker.record(family, resp, err)
+	return resp, err
+}
 
-	return resp, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
+// sendAllowed sends req through the middleware chain, waiting for the rate
+// limiter first.
+func (c *Client) sendAllowed(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if c.RateLimiter != nil {
+		if err := c.RateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return c.doer().Do(req)
 }
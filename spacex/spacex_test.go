@@ -3,10 +3,13 @@ package spacex
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+
+	"github.com/catdevman/go-spacex/spacex/query"
 )
 
 // setup sets up a test HTTP server along with a spacex.Client that is
@@ -39,7 +42,7 @@ func TestCapsulesService_GetCapsule(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	capsule, err := client.Capsules.GetCapsule(ctx, "5e9e2c5bf35918ed873b2664")
+	capsule, _, err := client.Capsules.GetCapsule(ctx, "5e9e2c5bf35918ed873b2664")
 	if err != nil {
 		t.Fatalf("Capsules.GetCapsule returned error: %v", err)
 	}
@@ -62,7 +65,7 @@ func TestCompanyService_GetCompanyInfo(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	company, err := client.Company.GetCompanyInfo(ctx)
+	company, _, err := client.Company.GetCompanyInfo(ctx)
 	if err != nil {
 		t.Fatalf("Company.GetCompanyInfo returned error: %v", err)
 	}
@@ -85,7 +88,7 @@ func TestCoresService_GetCore(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	core, err := client.Cores.GetCore(ctx, "5e9e28a6f35918c0803b265c")
+	core, _, err := client.Cores.GetCore(ctx, "5e9e28a6f35918c0803b265c")
 	if err != nil {
 		t.Fatalf("Cores.GetCore returned error: %v", err)
 	}
@@ -109,7 +112,7 @@ func TestCrewService_GetCrew(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	crew, err := client.Crew.GetCrew(ctx, "5ebf1a6e23a9a60006e03a7a")
+	crew, _, err := client.Crew.GetCrew(ctx, "5ebf1a6e23a9a60006e03a7a")
 	if err != nil {
 		t.Fatalf("Crew.GetCrew returned error: %v", err)
 	}
@@ -132,7 +135,7 @@ func TestDragonsService_GetDragon(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	dragon, err := client.Dragons.GetDragon(ctx, "5e9d058759b1ff74a7ad5f8f")
+	dragon, _, err := client.Dragons.GetDragon(ctx, "5e9d058759b1ff74a7ad5f8f")
 	if err != nil {
 		t.Fatalf("Dragons.GetDragon returned error: %v", err)
 	}
@@ -156,7 +159,7 @@ func TestHistoryService_GetHistory(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	history, err := client.History.GetHistory(ctx, "5e9d058759b1ff74a7ad5f8f")
+	history, _, err := client.History.GetHistory(ctx, "5e9d058759b1ff74a7ad5f8f")
 	if err != nil {
 		t.Fatalf("History.GetHistory returned error: %v", err)
 	}
@@ -180,7 +183,7 @@ func TestLandpadsService_GetLandpad(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	landpad, err := client.Landpads.GetLandpad(ctx, "5e9e3032383ecb267a34e7c7")
+	landpad, _, err := client.Landpads.GetLandpad(ctx, "5e9e3032383ecb267a34e7c7")
 	if err != nil {
 		t.Fatalf("Landpads.GetLandpad returned error: %v", err)
 	}
@@ -203,7 +206,7 @@ func TestLaunchesService_GetLaunch(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	launch, err := client.Launches.GetLaunch(ctx, "5eb87cd9ffd86e000604b32a")
+	launch, _, err := client.Launches.GetLaunch(ctx, "5eb87cd9ffd86e000604b32a")
 	if err != nil {
 		t.Fatalf("Launches.GetLaunch returned error: %v", err)
 	}
@@ -227,7 +230,7 @@ func TestLaunchpadsService_GetLaunchpad(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	launchpad, err := client.Launchpads.GetLaunchpad(ctx, "5e9e4502f5090995de566f86")
+	launchpad, _, err := client.Launchpads.GetLaunchpad(ctx, "5e9e4502f5090995de566f86")
 	if err != nil {
 		t.Fatalf("Launchpads.GetLaunchpad returned error: %v", err)
 	}
@@ -251,7 +254,7 @@ func TestPayloadsService_GetPayload(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	payload, err := client.Payloads.GetPayload(ctx, "5eb0e4c6b6c3bb0006eeb21e")
+	payload, _, err := client.Payloads.GetPayload(ctx, "5eb0e4c6b6c3bb0006eeb21e")
 	if err != nil {
 		t.Fatalf("Payloads.GetPayload returned error: %v", err)
 	}
@@ -274,7 +277,7 @@ func TestRoadsterService_GetRoadsterInfo(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	roadster, err := client.Roadster.GetRoadsterInfo(ctx)
+	roadster, _, err := client.Roadster.GetRoadsterInfo(ctx)
 	if err != nil {
 		t.Fatalf("Roadster.GetRoadsterInfo returned error: %v", err)
 	}
@@ -297,7 +300,7 @@ func TestRocketsService_GetRocket(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	rocket, err := client.Rockets.GetRocket(ctx, "5e9d0d95eda69955f709d1eb")
+	rocket, _, err := client.Rockets.GetRocket(ctx, "5e9d0d95eda69955f709d1eb")
 	if err != nil {
 		t.Fatalf("Rockets.GetRocket returned error: %v", err)
 	}
@@ -320,7 +323,7 @@ func TestShipsService_GetShip(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	ship, err := client.Ships.GetShip(ctx, "5ea6ed2e080df4000697c90a")
+	ship, _, err := client.Ships.GetShip(ctx, "5ea6ed2e080df4000697c90a")
 	if err != nil {
 		t.Fatalf("Ships.GetShip returned error: %v", err)
 	}
@@ -344,7 +347,7 @@ func TestStarlinkService_GetStarlink(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	starlink, err := client.Starlink.GetStarlink(ctx, "5eed770f096e59000698560d")
+	starlink, _, err := client.Starlink.GetStarlink(ctx, "5eed770f096e59000698560d")
 	if err != nil {
 		t.Fatalf("Starlink.GetStarlink returned error: %v", err)
 	}
@@ -354,3 +357,30 @@ func TestStarlinkService_GetStarlink(t *testing.T) {
 		t.Errorf("Starlink.GetStarlink returned %+v, want %+v", *starlink.Version, *want.Version)
 	}
 }
+
+func TestLaunchesService_QueryLaunches(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/launches/query", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Request method = %v, want %v", r.Method, "POST")
+		}
+		body, _ := io.ReadAll(r.Body)
+		want := `{"options":{"sort":"-date_utc","limit":1},"query":{"upcoming":{"$eq":true}}}` + "\n"
+		if string(body) != want {
+			t.Errorf("Request body = %s, want %s", body, want)
+		}
+		fmt.Fprint(w, `{"docs":[{"name":"Crew-10"}],"totalDocs":1}`)
+	})
+
+	ctx := context.Background()
+	q := query.New(query.Eq("upcoming", true), &query.Options{Sort: query.Fields{"-date_utc"}, Limit: 1})
+	results, _, err := client.Launches.QueryLaunches(ctx, q)
+	if err != nil {
+		t.Fatalf("Launches.QueryLaunches returned error: %v", err)
+	}
+	if len(results.Docs) != 1 || results.Docs[0].Name != "Crew-10" {
+		t.Errorf("Launches.QueryLaunches returned %+v", results.Docs)
+	}
+}
@@ -3,6 +3,7 @@ package spacex
 import (
 	"context"
 	"fmt"
+	"iter"
 )
 
 // StarlinkService handles communication with the starlink related
@@ -20,6 +21,15 @@ type Starlink struct {
 	HeightKm    *float64    `json:"height_km"`
 	VelocityKms *float64    `json:"velocity_kms"`
 	SpaceTrack  *SpaceTrack `json:"spaceTrack"`
+	ID          string      `json:"id"`
+
+	Document
+}
+
+// MarshalJSON implements json.Marshaler, including the fields in Extra.
+func (s Starlink) MarshalJSON() ([]byte, error) {
+	type starlink Starlink
+	return marshalModel(starlink(s), s.Extra)
 }
 
 // SpaceTrack represents space track data for a starlink satellite.
@@ -67,36 +77,57 @@ type SpaceTrack struct {
 	TLELINE2           *string  `json:"TLE_LINE2"`
 }
 
+// StarlinkQueryResults represents the result of a starlink query.
+type StarlinkQueryResults = Page[*Starlink]
+
 // ListAllStarlink lists all starlink satellites.
-func (s *StarlinkService) ListAllStarlink(ctx context.Context) ([]*Starlink, error) {
+func (s *StarlinkService) ListAllStarlink(ctx context.Context, opts ...CallOption) ([]*Starlink, *Response, error) {
 	u := "starlink"
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var starlink []*Starlink
-	_, err = s.client.do(ctx, req, &starlink)
+	resp, err := s.client.do(ctx, req, &starlink)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return starlink, nil
+	return starlink, resp, nil
+}
+
+// All iterates over all starlink satellites, decoding them one at a time.
+func (s *StarlinkService) All(ctx context.Context, opts ...CallOption) iter.Seq2[*Starlink, error] {
+	return streamList[Starlink](ctx, s.client, "starlink", opts...)
 }
 
 // GetStarlink retrieves a specific starlink satellite.
-func (s *StarlinkService) GetStarlink(ctx context.Context, id string) (*Starlink, error) {
+func (s *StarlinkService) GetStarlink(ctx context.Context, id string, opts ...CallOption) (*Starlink, *Response, error) {
 	u := fmt.Sprintf("starlink/%s", id)
-	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	req, err := s.client.newRequest(ctx, "GET", u, nil, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	starlink := new(Starlink)
-	_, err = s.client.do(ctx, req, starlink)
+	resp, err := s.client.do(ctx, req, starlink)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return starlink, nil
+	return starlink, resp, nil
+}
+
+// GetMany retrieves the starlink satellites with the given IDs, in order.
+// IDs that don't exist have nil entries and are reported in a
+// *MissingIDsError.
+func (s *StarlinkService) GetMany(ctx context.Context, ids []string, opts ...CallOption) ([]*Starlink, *Response, error) {
+	return getMany(ctx, s.client, "starlink", ids, func(sat *Starlink) string { return sat.ID }, opts)
+}
+
+// QueryStarlink queries for starlink satellites. The query can be built with
+// the query package.
+func (s *StarlinkService) QueryStarlink(ctx context.Context, query map[string]interface{}, opts ...CallOption) (*StarlinkQueryResults, *Response, error) {
+	return queryPage[*Starlink](ctx, s.client, "starlink", query, opts)
 }
@@ -0,0 +1,90 @@
+package spacex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// streamList returns an iterator over the elements of the JSON array served
+// at u. Elements are decoded one at a time as they arrive; see Client for
+// when the response body is buffered. Iteration stops after the first error.
+func streamList[T any](ctx context.Context, c *Client, u string, opts ...CallOption) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		req, err := c.newRequest(ctx, "GET", u, nil, opts...)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		ctx, cancel := callContext(ctx, req)
+		defer cancel()
+
+		ctx, call := c.startCall(ctx, req)
+		resp, body, err := c.bareDo(ctx, req)
+		if err != nil {
+			call.end(resp, err)
+			yield(nil, err)
+			return
+		}
+		body = call.countBody(body)
+		defer body.Close()
+
+		// fail ends the call with err and yields it.
+		fail := func(err error) {
+			call.end(resp, err)
+			yield(nil, err)
+		}
+
+		dec := json.NewDecoder(body)
+		if err := expectDelim(dec, '['); err != nil {
+			fail(err)
+			return
+		}
+		for dec.More() {
+			v := new(T)
+			if c.driftReport != nil || c.rawDocuments {
+				var raw json.RawMessage
+				err = dec.Decode(&raw)
+				if err == nil {
+					// Report fields as elements of the list, like do.
+					err = c.decodeDocument(endpointPattern(c.endpoint(req)), "[]", raw, v)
+				}
+			} else {
+				err = dec.Decode(v)
+			}
+			if err != nil {
+				fail(err)
+				return
+			}
+			if !yield(v, nil) {
+				call.end(resp, nil)
+				return
+			}
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			fail(err)
+			return
+		}
+
+		// Drain the remainder so the body is recorded for caching.
+		if _, err := io.Copy(io.Discard, body); err != nil {
+			fail(err)
+			return
+		}
+		call.end(resp, nil)
+	}
+}
+
+// expectDelim reads the next token from dec and checks that it is delim.
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("unexpected JSON token %v, want %v", tok, delim)
+	}
+	return nil
+}
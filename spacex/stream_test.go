@@ -0,0 +1,102 @@
+package spacex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestStarlinkService_All(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/starlink", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Request method = %v, want %v", r.Method, "GET")
+		}
+		fmt.Fprint(w, `[{"version":"v0.9"},{"version":"v1.0"},{"version":"v1.5"}]`)
+	})
+
+	var versions []string
+	for sat, err := range client.Starlink.All(context.Background()) {
+		if err != nil {
+			t.Fatalf("Starlink.All returned error: %v", err)
+		}
+		versions = append(versions, *sat.Version)
+	}
+
+	if got, want := fmt.Sprint(versions), "[v0.9 v1.0 v1.5]"; got != want {
+		t.Errorf("Starlink.All yielded %v, want %v", got, want)
+	}
+}
+
+func TestLaunchesService_AllStopsEarly(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/launches", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"name":"FalconSat"},{"name":"DemoSat"},{"name":"Trailblazer"}]`)
+	})
+
+	n := 0
+	for _, err := range client.Launches.All(context.Background()) {
+		if err != nil {
+			t.Fatalf("Launches.All returned error: %v", err)
+		}
+		n++
+		if n == 2 {
+			break
+		}
+	}
+	if n != 2 {
+		t.Errorf("iterated %d launches, want 2", n)
+	}
+}
+
+func TestCoresService_AllErrors(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/cores", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"serial":"B1051"}`)
+	})
+
+	var lastErr error
+	for _, err := range client.Cores.All(context.Background()) {
+		lastErr = err
+	}
+	if lastErr == nil {
+		t.Errorf("Cores.All yielded no error for a non-array body")
+	}
+}
+
+func TestRocketsService_AllCached(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.Cache = NewMemoryCache(0)
+	client.CachePolicy = &CachePolicy{DefaultTTL: time.Hour}
+
+	calls := 0
+	mux.HandleFunc("/rockets", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `[{"name":"Falcon 1"},{"name":"Falcon 9"}]`)
+	})
+
+	for i := 0; i < 2; i++ {
+		n := 0
+		for _, err := range client.Rockets.All(context.Background()) {
+			if err != nil {
+				t.Fatalf("Rockets.All returned error: %v", err)
+			}
+			n++
+		}
+		if n != 2 {
+			t.Errorf("iteration %d: got %d rockets, want 2", i, n)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("server saw %d calls, want 1", calls)
+	}
+}
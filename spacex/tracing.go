@@ -0,0 +1,151 @@
+package spacex
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// Tracer starts a span around every API call made by a Client. It lets the
+// client report to any tracing backend without depending on one; see the
+// oteltrace package for an OpenTelemetry adapter.
+type Tracer interface {
+	// Start starts a span named name as a child of any span in ctx and
+	// returns a context carrying the new span. The returned context is used
+	// for the HTTP request, so that the span propagates to the transport.
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a single traced API call.
+type Span interface {
+	// SetAttributes records attributes describing the call.
+	SetAttributes(attrs ...Attribute)
+	// RecordError records the error the call failed with.
+	RecordError(err error)
+	// End completes the span.
+	End()
+}
+
+// Attribute is a key-value pair describing a traced call. Value is a string,
+// an int or an int64.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// Attribute keys set on the spans of API calls.
+const (
+	AttrService    = "spacex.service"            // service, such as "launches"
+	AttrEndpoint   = "spacex.endpoint"           // endpoint pattern, such as "launches/{id}"
+	AttrMethod     = "http.request.method"       // HTTP method
+	AttrURL        = "url.full"                  // request URL
+	AttrStatusCode = "http.response.status_code" // HTTP status code, if a response was received
+	AttrBodySize   = "http.response.body.size"   // response body bytes read
+	AttrRetries    = "spacex.retries"            // attempts made after the first
+	AttrCacheHit   = "spacex.cache_hit"          // "client" when served from Cache, "revalidated" on 304
+)
+
+// callInfo collects the outcome of an API call while it is in flight. It is
+// carried in the request context so that the retry loop can update it.
+type callInfo struct {
+	attempts atomic.Int32
+	bytes    atomic.Int64
+}
+
+type callInfoKey struct{}
+
+// callInfoFrom returns the callInfo in ctx, or nil.
+func callInfoFrom(ctx context.Context) *callInfo {
+	info, _ := ctx.Value(callInfoKey{}).(*callInfo)
+	return info
+}
+
+// apiCall is an API call being traced or measured.
+type apiCall struct {
+	client  *Client
+	pattern string
+	start   time.Time
+	span    Span
+	info    *callInfo
+}
+
+// startCall starts tracing and measuring req, returning the context to send
+// it with. It returns a nil call if neither a Tracer nor Metrics are
+// configured.
+func (c *Client) startCall(ctx context.Context, req *http.Request) (context.Context, *apiCall) {
+	if c.Tracer == nil && c.Metrics == nil {
+		return ctx, nil
+	}
+	endpoint := c.endpoint(req)
+	call := &apiCall{
+		client:  c,
+		pattern: endpointPattern(endpoint),
+		start:   time.Now(),
+		info:    new(callInfo),
+	}
+	if c.Tracer != nil {
+		ctx, call.span = c.Tracer.Start(ctx, "spacex "+call.pattern)
+		call.span.SetAttributes(
+			Attribute{AttrService, serviceFamily(endpoint)},
+			Attribute{AttrEndpoint, call.pattern},
+			Attribute{AttrMethod, req.Method},
+			Attribute{AttrURL, req.URL.String()},
+		)
+	}
+	return context.WithValue(ctx, callInfoKey{}, call.info), call
+}
+
+// countBody wraps body so that the bytes read are added to the call.
+func (a *apiCall) countBody(body io.ReadCloser) io.ReadCloser {
+	if a == nil {
+		return body
+	}
+	return &countingBody{ReadCloser: body, n: &a.info.bytes}
+}
+
+// end records the outcome of the call, ending its span.
+func (a *apiCall) end(resp *Response, err error) {
+	if a == nil {
+		return
+	}
+	retries := max(int(a.info.attempts.Load())-1, 0)
+	if a.client.Metrics != nil {
+		a.client.Metrics.observe(a.pattern, time.Since(a.start), retries, resp, err)
+	}
+	if a.span == nil {
+		return
+	}
+
+	attrs := []Attribute{
+		{AttrBodySize, a.info.bytes.Load()},
+		{AttrRetries, retries},
+	}
+	if resp != nil && resp.Response != nil {
+		attrs = append(attrs, Attribute{AttrStatusCode, resp.StatusCode})
+		switch {
+		case resp.FromCache:
+			attrs = append(attrs, Attribute{AttrCacheHit, "client"})
+		case resp.NotModified:
+			attrs = append(attrs, Attribute{AttrCacheHit, "revalidated"})
+		}
+	}
+	a.span.SetAttributes(attrs...)
+	if err != nil {
+		a.span.RecordError(err)
+	}
+	a.span.End()
+}
+
+// countingBody is a response body counting the bytes read from it.
+type countingBody struct {
+	io.ReadCloser
+	n *atomic.Int64
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n.Add(int64(n))
+	return n, err
+}
@@ -0,0 +1,124 @@
+package spacex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type testSpanKey struct{}
+
+// testTracer records the spans it starts.
+type testTracer struct {
+	mu    sync.Mutex
+	spans []*testSpan
+}
+
+type testSpan struct {
+	name  string
+	attrs map[string]any
+	err   error
+	ended bool
+}
+
+func (t *testTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s := &testSpan{name: name, attrs: make(map[string]any)}
+	t.spans = append(t.spans, s)
+	return context.WithValue(ctx, testSpanKey{}, s), s
+}
+
+func (s *testSpan) SetAttributes(attrs ...Attribute) {
+	for _, a := range attrs {
+		s.attrs[a.Key] = a.Value
+	}
+}
+
+func (s *testSpan) RecordError(err error) { s.err = err }
+func (s *testSpan) End()                  { s.ended = true }
+
+func TestClient_Tracer(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	tracer := new(testTracer)
+	client.Tracer = tracer
+	client.RetryPolicy = testRetryPolicy()
+
+	attempts := 0
+	mux.HandleFunc("/starlink/5eed770f096e59000698560d", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `{"version":"v1.0"}`)
+	})
+
+	var spanInTransport any
+	client.Use(func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			spanInTransport = req.Context().Value(testSpanKey{})
+			return next.Do(req)
+		})
+	})
+
+	if _, _, err := client.Starlink.GetStarlink(context.Background(), "5eed770f096e59000698560d"); err != nil {
+		t.Fatalf("Starlink.GetStarlink returned error: %v", err)
+	}
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("tracer started %d spans, want 1", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if span.name != "spacex starlink/{id}" || !span.ended || span.err != nil {
+		t.Errorf("span = %+v, want ended span named %q without error", span, "spacex starlink/{id}")
+	}
+	if spanInTransport != span {
+		t.Errorf("request context carries span %v, want %v", spanInTransport, span)
+	}
+	want := map[string]any{
+		AttrService:    "starlink",
+		AttrEndpoint:   "starlink/{id}",
+		AttrMethod:     "GET",
+		AttrURL:        client.BaseURL.String() + "starlink/5eed770f096e59000698560d",
+		AttrStatusCode: http.StatusOK,
+		AttrBodySize:   int64(len(`{"version":"v1.0"}`)),
+		AttrRetries:    1,
+	}
+	if !reflect.DeepEqual(span.attrs, want) {
+		t.Errorf("span attributes = %v, want %v", span.attrs, want)
+	}
+}
+
+func TestClient_TracerError(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	tracer := new(testTracer)
+	client.Tracer = tracer
+
+	mux.HandleFunc("/rockets", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+	})
+
+	for _, err := range client.Rockets.All(context.Background()) {
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("Rockets.All yielded %v, want ErrNotFound", err)
+		}
+	}
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("tracer started %d spans, want 1", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if !errors.Is(span.err, ErrNotFound) || !span.ended {
+		t.Errorf("span error = %v, ended = %v; want ErrNotFound, true", span.err, span.ended)
+	}
+	if got := span.attrs[AttrStatusCode]; got != http.StatusNotFound {
+		t.Errorf("span status code = %v, want %v", got, http.StatusNotFound)
+	}
+}